@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretKeyReference selects a key of a Secret in any namespace.
+type SecretKeyReference struct {
+	// Namespace of the Secret
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Name of the Secret
+	// +required
+	Name string `json:"name"`
+
+	// Key of the Secret to read
+	// +required
+	Key string `json:"key"`
+}
+
+// ClusterUpbotAccountSpec defines the desired state of ClusterUpbotAccount
+type ClusterUpbotAccountSpec struct {
+	// TokenSecretRef selects the key of the Secret that holds the Upbot API token
+	// +required
+	TokenSecretRef SecretKeyReference `json:"tokenSecretRef"`
+
+	APISettings `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=cupbotacct
+
+// ClusterUpbotAccount holds the credentials of an Upbot account usable by Monitors of every namespace
+type ClusterUpbotAccount struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of ClusterUpbotAccount
+	// +required
+	Spec ClusterUpbotAccountSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterUpbotAccountList contains a list of ClusterUpbotAccount
+type ClusterUpbotAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpbotAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpbotAccount{}, &ClusterUpbotAccountList{})
+}
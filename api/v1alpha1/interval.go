@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Intervals are the check intervals accepted by the Upbot API.
+var Intervals = []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute}
+
+// Interval is a duration such as "30s" or "5m", serialized like
+// metav1.Duration. A bare number of seconds such as "30" is accepted too:
+// spec.interval used to be a number of seconds, and Monitors stored in that
+// format must keep decoding.
+// +kubebuilder:validation:Type=string
+type Interval struct {
+	Duration time.Duration `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Interval) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		// Numbers are seconds, like numeric strings.
+		var seconds int64
+		if json.Unmarshal(b, &seconds) != nil {
+			return err
+		}
+		str = strconv.FormatInt(seconds, 10)
+	}
+
+	if seconds, err := strconv.ParseInt(str, 10, 64); err == nil {
+		i.Duration = time.Duration(seconds) * time.Second
+		return nil
+	}
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	i.Duration = duration
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Duration.String())
+}
+
+// ToUnstructured implements the value.UnstructuredConverter interface.
+func (i Interval) ToUnstructured() interface{} {
+	return i.Duration.String()
+}
+
+// String returns the interval formatted as a duration, e.g. "30s".
+func (i Interval) String() string {
+	return i.Duration.String()
+}
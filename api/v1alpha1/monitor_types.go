@@ -17,27 +17,205 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// MonitorType is the kind of check Upbot performs against the target. The
+// Upbot API only runs http and ping checks, https is an http check that
+// requires an https:// target.
+// +kubebuilder:validation:Enum=http;https;ping
+type MonitorType string
+
+const (
+	// MonitorTypeHTTP checks that an http:// or https:// URL responds.
+	MonitorTypeHTTP MonitorType = "http"
+	// MonitorTypeHTTPS checks that an https:// URL responds.
+	MonitorTypeHTTPS MonitorType = "https"
+	// MonitorTypePing checks that a host answers ICMP echo requests.
+	MonitorTypePing MonitorType = "ping"
+)
+
 // MonitorSpec defines the desired state of Monitor
+// +kubebuilder:validation:XValidation:rule="has(self.target) && size(self.target) > 0",message="target is required"
+// +kubebuilder:validation:XValidation:rule="self.type != 'http' || !has(self.target) || self.target.matches('^https?://[^ /?#]+([/?#][^ ]*)?$')",message="target must be an http:// or https:// URL for http monitors"
+// +kubebuilder:validation:XValidation:rule="self.type != 'https' || !has(self.target) || self.target.matches('^https://[^ /?#]+([/?#][^ ]*)?$')",message="target must be an https:// URL for https monitors"
+// +kubebuilder:validation:XValidation:rule="self.type != 'ping' || !has(self.target) || self.target.matches('^([A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?|[0-9A-Fa-f:.]+)$')",message="target must be a hostname or IP address for ping monitors"
+// +kubebuilder:validation:XValidation:rule="!has(self.http) || self.type in ['http', 'https']",message="http can only be set for http and https monitors"
 type MonitorSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
-	// The following markers will use OpenAPI v3 schema to validate the value
-	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
+	// Type is the kind of check Upbot performs against the target
+	// +required
+	Type MonitorType `json:"type"`
+
+	// AccountRef selects the Upbot account the monitor is created in. Defaults
+	// to the account of the operator's token
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// DisplayName is the name of the monitor in Upbot. Defaults to the operator's
+	// --monitor-name-template
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Target is the URL, host or host:port checked by Upbot. Its format depends on Type
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Interval is the time between two checks. Upbot accepts 30s, 1m, 2m, 5m and
+	// 10m, a bare number is read as seconds. The bounds configured with the
+	// operator's --monitor-min-interval and --monitor-max-interval are enforced by
+	// the validating webhook when it is enabled, and otherwise when reconciling.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+|([0-9]+(\.[0-9]+)?(ms|s|m|h))+)$`
+	// +kubebuilder:validation:XValidation:rule="(self.matches('^[0-9]+$') ? duration(self + 's') : duration(self)) in [duration('30s'), duration('1m'), duration('2m'), duration('5m'), duration('10m')]",message="interval must be one of 30s, 1m, 2m, 5m or 10m"
+	// +required
+	Interval Interval `json:"interval"`
+
+	// RetryCount is the number of failed checks Upbot retries before it reports
+	// the monitor as down. Defaults to the operator's --monitor-default-retry-count
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	RetryCount *int32 `json:"retryCount,omitempty"`
+
+	// ExternalID is the ID of an existing Upbot monitor to take over instead of
+	// creating a new one. The spec is applied to it on the first sync
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="externalID is immutable"
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// AdoptByName takes over the Upbot monitor with the same name, if there is
+	// exactly one, instead of creating a new one. Ignored when ExternalID is set
+	// +optional
+	AdoptByName bool `json:"adoptByName,omitempty"`
+
+	// RecreateOnMissing recreates the monitor when it was deleted in Upbot. When
+	// false the Monitor reports the missing remote monitor and stops syncing
+	// +kubebuilder:default=true
+	// +optional
+	RecreateOnMissing *bool `json:"recreateOnMissing,omitempty"`
+
+	// DeletionPolicy controls what happens to the Upbot monitor when the Monitor
+	// is deleted. Delete removes it, Retain keeps it in Upbot, unmanaged.
+	// Defaults to the operator's --monitor-default-deletion-policy
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DriftPolicy controls what happens when the periodic resync finds that the
+	// monitor was changed in Upbot. Correct overwrites the remote settings with
+	// the spec, Report only sets the Drifted condition
+	// +kubebuilder:default=Correct
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// HTTP configures the request of http and https monitors
+	// +optional
+	HTTP *HTTPOptions `json:"http,omitempty"`
+}
+
+// HTTPOptions configures how Upbot performs HTTP based checks. The Upbot API
+// only takes the target URL of a check, so the options are limited to what can
+// be expressed in the URL: request headers, for instance, can't be set.
+type HTTPOptions struct {
+	// QueryParams are appended to the target URL, e.g. to pass an access token
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	QueryParams []HTTPQueryParam `json:"queryParams,omitempty"`
+
+	// BasicAuth sends the credentials from a Secret as HTTP basic
+	// authentication, as the user information of the target URL
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+}
+
+// HTTPQueryParam is a query parameter added to the target URL.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="exactly one of value and valueFrom must be set"
+type HTTPQueryParam struct {
+	// Name of the query parameter
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Value of the query parameter
+	// +optional
+	Value string `json:"value,omitempty"`
 
-	// foo is an example field of Monitor. Edit monitor_types.go to remove/update
+	// ValueFrom reads the value of the query parameter from a Secret
 	// +optional
-	Target   string `json:"target,omitempty"`
-	Interval string `json:"interval,omitempty"`
-	Type     string `json:"type,omitempty"`
-	// Foo *string `json:"foo,omitempty"`
+	ValueFrom *SecretValueSource `json:"valueFrom,omitempty"`
 }
 
+// BasicAuth holds references to the credentials used for HTTP basic authentication.
+type BasicAuth struct {
+	// Username selects the key of a Secret in the namespace of the Monitor holding the username
+	// +required
+	Username corev1.SecretKeySelector `json:"username"`
+
+	// Password selects the key of a Secret in the namespace of the Monitor holding the password
+	// +required
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// SecretValueSource selects a value stored in a Secret.
+type SecretValueSource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace of the Monitor
+	// +required
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// AccountKind is the kind of an Upbot account resource.
+// +kubebuilder:validation:Enum=UpbotAccount;ClusterUpbotAccount
+type AccountKind string
+
+const (
+	// AccountKindUpbotAccount refers to an UpbotAccount in the namespace of the Monitor.
+	AccountKindUpbotAccount AccountKind = "UpbotAccount"
+	// AccountKindClusterUpbotAccount refers to a ClusterUpbotAccount.
+	AccountKindClusterUpbotAccount AccountKind = "ClusterUpbotAccount"
+)
+
+// AccountReference refers to an UpbotAccount or a ClusterUpbotAccount.
+type AccountReference struct {
+	// Kind of the account
+	// +kubebuilder:default=UpbotAccount
+	// +optional
+	Kind AccountKind `json:"kind,omitempty"`
+
+	// Name of the account
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+}
+
+// DeletionPolicy is the action taken on the Upbot monitor when the Monitor is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the Upbot monitor along with the Monitor.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the Upbot monitor and its history.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// DriftPolicy is the action taken when the remote monitor differs from the spec.
+// +kubebuilder:validation:Enum=Correct;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyCorrect applies the spec again when drift is detected.
+	DriftPolicyCorrect DriftPolicy = "Correct"
+	// DriftPolicyReport only reports drift in the Drifted condition.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
 // MonitorStatus defines the observed state of Monitor.
 type MonitorStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -46,14 +224,64 @@ type MonitorStatus struct {
 	// ExternalID is the ID of the monitor in the external system
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
+
+	// State is the last known state of the monitor in Upbot, e.g. online or offline.
+	// It is the only live data the Upbot API reports: the time and response time
+	// of the last check, the uptime and the current incident aren't available
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the last time the spec was successfully applied to Upbot
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// AppliedHash is the hash of the last payload successfully sent to Upbot, with
+	// Secret values replaced by the resourceVersion of their Secret. The
+	// controller skips the API call when the desired payload hashes the same
+	// +optional
+	AppliedHash string `json:"appliedHash,omitempty"`
+
+	// LastDriftCheckTime is the last time the remote monitor was compared with the spec
+	// +optional
+	LastDriftCheckTime *metav1.Time `json:"lastDriftCheckTime,omitempty"`
+
+	// LastError is the message of the last error returned while syncing with Upbot
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Conditions represent the latest available observations of the Monitor's state
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// Condition types reported on a Monitor.
+const (
+	// ConditionReady indicates that the monitor exists in Upbot and matches the spec.
+	ConditionReady = "Ready"
+	// ConditionSynced indicates whether the last attempt to apply the spec to Upbot succeeded.
+	ConditionSynced = "Synced"
+	// ConditionRemoteHealthy reflects whether Upbot reports the monitored target as up.
+	ConditionRemoteHealthy = "RemoteHealthy"
+	// ConditionSecretsResolved indicates whether all Secrets referenced by the spec could be read.
+	ConditionSecretsResolved = "SecretsResolved"
+	// ConditionDrifted indicates whether the monitor in Upbot differs from the spec.
+	ConditionDrifted = "Drifted"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target`
 // +kubebuilder:printcolumn:name="Interval",type=string,JSONPath=`.spec.interval`
-// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
-// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="State of the monitor in Upbot, the only live data its API reports"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.externalID`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Monitor is the Schema for the monitors API
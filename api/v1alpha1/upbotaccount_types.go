@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpbotAccountSpec defines the desired state of UpbotAccount
+type UpbotAccountSpec struct {
+	// TokenSecretRef selects the key of a Secret in the namespace of the
+	// UpbotAccount that holds the Upbot API token
+	// +required
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+
+	ClientSettings `json:",inline"`
+}
+
+// APISettings override the operator-wide settings of the client talking to
+// the Upbot API for the Monitors of an account. The endpoint settings can
+// only be set on a ClusterUpbotAccount: in a namespaced account, they would
+// let any namespace send the requests of the operator, and the token they
+// carry, to an arbitrary host
+type APISettings struct {
+	// BaseURL of the Upbot API, e.g. to use a staging instance
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// ProxyURL of the HTTP proxy the requests go through
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// CABundle is a PEM bundle of certificate authorities trusted in addition
+	// to the system ones
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	ClientSettings `json:",inline"`
+}
+
+// ClientSettings are the API settings that any account can override
+type ClientSettings struct {
+	// Timeout of a request to the Upbot API
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// UserAgent sent with the requests
+	// +optional
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=upbotacct
+
+// UpbotAccount holds the credentials of an Upbot account for the Monitors of its namespace
+type UpbotAccount struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of UpbotAccount
+	// +required
+	Spec UpbotAccountSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// UpbotAccountList contains a list of UpbotAccount
+type UpbotAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UpbotAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UpbotAccount{}, &UpbotAccountList{})
+}
@@ -21,16 +21,222 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APISettings) DeepCopyInto(out *APISettings) {
+	*out = *in
+	in.ClientSettings.DeepCopyInto(&out.ClientSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APISettings.
+func (in *APISettings) DeepCopy() *APISettings {
+	if in == nil {
+		return nil
+	}
+	out := new(APISettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountReference) DeepCopyInto(out *AccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountReference.
+func (in *AccountReference) DeepCopy() *AccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSettings) DeepCopyInto(out *ClientSettings) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSettings.
+func (in *ClientSettings) DeepCopy() *ClientSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpbotAccount) DeepCopyInto(out *ClusterUpbotAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpbotAccount.
+func (in *ClusterUpbotAccount) DeepCopy() *ClusterUpbotAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpbotAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpbotAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpbotAccountList) DeepCopyInto(out *ClusterUpbotAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterUpbotAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpbotAccountList.
+func (in *ClusterUpbotAccountList) DeepCopy() *ClusterUpbotAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpbotAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpbotAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpbotAccountSpec) DeepCopyInto(out *ClusterUpbotAccountSpec) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+	in.APISettings.DeepCopyInto(&out.APISettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpbotAccountSpec.
+func (in *ClusterUpbotAccountSpec) DeepCopy() *ClusterUpbotAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpbotAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPOptions) DeepCopyInto(out *HTTPOptions) {
+	*out = *in
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make([]HTTPQueryParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPOptions.
+func (in *HTTPOptions) DeepCopy() *HTTPOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPQueryParam) DeepCopyInto(out *HTTPQueryParam) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(SecretValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPQueryParam.
+func (in *HTTPQueryParam) DeepCopy() *HTTPQueryParam {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPQueryParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Interval) DeepCopyInto(out *Interval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Interval.
+func (in *Interval) DeepCopy() *Interval {
+	if in == nil {
+		return nil
+	}
+	out := new(Interval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Monitor) DeepCopyInto(out *Monitor) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitor.
@@ -86,6 +292,27 @@ func (in *MonitorList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
 	*out = *in
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	out.Interval = in.Interval
+	if in.RetryCount != nil {
+		in, out := &in.RetryCount, &out.RetryCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecreateOnMissing != nil {
+		in, out := &in.RecreateOnMissing, &out.RecreateOnMissing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorSpec.
@@ -101,6 +328,21 @@ func (in *MonitorSpec) DeepCopy() *MonitorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorStatus) DeepCopyInto(out *MonitorStatus) {
 	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDriftCheckTime != nil {
+		in, out := &in.LastDriftCheckTime, &out.LastDriftCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorStatus.
@@ -112,3 +354,109 @@ func (in *MonitorStatus) DeepCopy() *MonitorStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretValueSource) DeepCopyInto(out *SecretValueSource) {
+	*out = *in
+	in.SecretKeyRef.DeepCopyInto(&out.SecretKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretValueSource.
+func (in *SecretValueSource) DeepCopy() *SecretValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpbotAccount) DeepCopyInto(out *UpbotAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpbotAccount.
+func (in *UpbotAccount) DeepCopy() *UpbotAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(UpbotAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpbotAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpbotAccountList) DeepCopyInto(out *UpbotAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UpbotAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpbotAccountList.
+func (in *UpbotAccountList) DeepCopy() *UpbotAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(UpbotAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpbotAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpbotAccountSpec) DeepCopyInto(out *UpbotAccountSpec) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+	in.ClientSettings.DeepCopyInto(&out.ClientSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpbotAccountSpec.
+func (in *UpbotAccountSpec) DeepCopy() *UpbotAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpbotAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,30 +17,41 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"flag"
-	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
-	upbot "github.com/upbothq/upbot-go-sdk"
+	upbotsdk "github.com/upbothq/upbot-go-sdk"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
 	"github.com/upbothq/operator/internal/controller"
+	"github.com/upbothq/operator/internal/upbot"
+	webhookmonitoringv1alpha1 "github.com/upbothq/operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -63,7 +74,25 @@ func main() {
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
 	var enableIngressWatcher bool
-	var ingressWatcherInterval string
+	var ingressWatcherInterval time.Duration
+	var enableWebhooks bool
+	var minMonitorInterval, maxMonitorInterval time.Duration
+	var defaultRetryCount int
+	var resyncPeriod time.Duration
+	var ledgerNamespace string
+	var statusPollInterval, statusPollBatchDelay time.Duration
+	var statusPollBatchSize int
+	var defaultDeletionPolicy string
+	var clusterID string
+	var nameTemplate string
+	var enableOrphanGC, orphanGCDryRun bool
+	var orphanGCInterval, orphanGCGracePeriod time.Duration
+	var tokenFile, tokenSecret, tokenSecretKey string
+	var tokenReloadInterval time.Duration
+	var apiOpts upbot.Options
+	var caBundleFile string
+	var apiRateLimit float64
+	var apiRateBurst int
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
@@ -76,8 +105,78 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&enableIngressWatcher, "enable-ingress-watcher", false,
 		"Enable the Ingress Watcher controller that automatically creates Monitor resources for Ingress resources.")
-	flag.StringVar(&ingressWatcherInterval, "ingress-watcher-interval", "30",
-		"Default interval for monitors created by the Ingress Watcher (e.g., '30', '60', '300').")
+	flag.DurationVar(&ingressWatcherInterval, "ingress-watcher-interval", 30*time.Second,
+		"Default interval for monitors created by the Ingress Watcher, one of the intervals accepted by Upbot: "+
+			"'30s', '1m', '2m', '5m' or '10m'.")
+	flag.DurationVar(&minMonitorInterval, "monitor-min-interval", 30*time.Second,
+		"Shortest spec.interval accepted for a Monitor. Set it to the minimum allowed by your Upbot plan. "+
+			"Use 0 to disable the bound. The CRD only admits the intervals supported by Upbot (30s to 10m): "+
+			"narrower bounds are rejected at admission by the webhook (--enable-webhooks), and otherwise "+
+			"reported at reconcile time with the InvalidSpec reason.")
+	flag.DurationVar(&maxMonitorInterval, "monitor-max-interval", 10*time.Minute,
+		"Longest spec.interval accepted for a Monitor. Use 0 to disable the bound. "+
+			"Enforced like --monitor-min-interval.")
+	flag.IntVar(&defaultRetryCount, "monitor-default-retry-count", 0,
+		"Retry count (0-10) used for Monitors that don't set spec.retryCount.")
+	flag.StringVar(&defaultDeletionPolicy, "monitor-default-deletion-policy", string(monitoringv1alpha1.DeletionPolicyDelete),
+		"What happens to the Upbot monitor of a deleted Monitor that doesn't set spec.deletionPolicy: "+
+			"Delete removes it, Retain keeps it in Upbot, no longer managed by the operator.")
+	flag.DurationVar(&resyncPeriod, "monitor-resync-period", 10*time.Minute,
+		"How often each Monitor is compared with Upbot to detect changes made outside the operator. "+
+			"Use 0 to disable drift detection.")
+	flag.StringVar(&clusterID, "cluster-id", "",
+		"Identifies this cluster in the {{cluster}} placeholder of --monitor-name-template. Defaults to the UID "+
+			"of the kube-system namespace.")
+	flag.StringVar(&nameTemplate, "monitor-name-template", controller.DefaultNameTemplate,
+		"Name of the Upbot monitor of a Monitor that doesn't set spec.displayName. "+
+			"Supports the {{cluster}}, {{namespace}} and {{name}} placeholders, e.g. '{{cluster}}/{{namespace}}/{{name}}'.")
+	flag.StringVar(&ledgerNamespace, "monitor-ledger-namespace", "",
+		"Namespace of the "+controller.DefaultLedgerName+" ConfigMap recording the Upbot monitors managed by "+
+			"the operator. Defaults to the namespace the operator runs in.")
+	flag.BoolVar(&enableOrphanGC, "enable-orphan-gc", false,
+		"Periodically delete the Upbot monitors managed by the operator whose Monitor no longer exists. "+
+			"The managed monitors are recorded in the ConfigMap of --monitor-ledger-namespace.")
+	flag.DurationVar(&orphanGCInterval, "orphan-gc-interval", time.Hour,
+		"Time between two passes of the orphaned monitor garbage collection.")
+	flag.DurationVar(&orphanGCGracePeriod, "orphan-gc-grace-period", time.Hour,
+		"How long an Upbot monitor has to be orphaned before the garbage collection deletes it.")
+	flag.BoolVar(&orphanGCDryRun, "orphan-gc-dry-run", false,
+		"Only log the orphaned Upbot monitors the garbage collection would delete.")
+	flag.StringVar(&tokenFile, "upbot-token-file", "",
+		"File containing the Upbot API token of the default account, e.g. a mounted Secret. "+
+			"Takes precedence over --upbot-token-secret and UPBOT_TOKEN.")
+	flag.StringVar(&tokenSecret, "upbot-token-secret", "",
+		"Secret containing the Upbot API token of the default account, as 'namespace/name'. "+
+			"Takes precedence over UPBOT_TOKEN.")
+	flag.StringVar(&tokenSecretKey, "upbot-token-secret-key", "token",
+		"Key of the token in --upbot-token-secret.")
+	flag.DurationVar(&tokenReloadInterval, "upbot-token-reload-interval", 30*time.Second,
+		"How often the token file or Secret is read again to pick up a rotated token.")
+	flag.StringVar(&apiOpts.BaseURL, "upbot-base-url", "",
+		"Base URL of the Upbot API, e.g. to use a staging instance. Defaults to the public API.")
+	flag.StringVar(&apiOpts.ProxyURL, "upbot-proxy-url", "",
+		"HTTP proxy for the requests to the Upbot API. Defaults to HTTP_PROXY, HTTPS_PROXY and NO_PROXY.")
+	flag.StringVar(&caBundleFile, "upbot-ca-bundle", "",
+		"PEM file of certificate authorities trusted for the Upbot API in addition to the system ones.")
+	flag.DurationVar(&apiOpts.Timeout, "upbot-timeout", 30*time.Second,
+		"Timeout of a request to the Upbot API. Use 0 to disable it.")
+	flag.StringVar(&apiOpts.UserAgent, "upbot-user-agent", "",
+		"User-Agent sent to the Upbot API. Defaults to the SDK one.")
+	flag.Float64Var(&apiRateLimit, "upbot-rate-limit", 5,
+		"Requests per second sent to the Upbot API, shared by every controller and account. Use 0 to disable the limit.")
+	flag.IntVar(&apiRateBurst, "upbot-rate-burst", 10,
+		"Number of requests that can be sent to the Upbot API at once above --upbot-rate-limit.")
+	flag.DurationVar(&statusPollInterval, "status-poll-interval", time.Minute,
+		"How often the state of each monitor (up or down) is read from Upbot into the Monitor "+
+			"status. Each pass lists the monitors of every account once. Use 0 to disable the poller.")
+	flag.IntVar(&statusPollBatchSize, "status-poll-batch-size", 50,
+		"Number of Monitor statuses written by the poller before pausing for --status-poll-batch-delay. "+
+			"Use 0 to write them all at once.")
+	flag.DurationVar(&statusPollBatchDelay, "status-poll-batch-delay", time.Second,
+		"Pause of the status poller between two batches of Monitor status writes.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable the validating webhook for Monitor resources, which rejects intervals outside "+
+			"--monitor-min-interval and --monitor-max-interval. Requires the webhook certificate to be provisioned.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
@@ -96,6 +195,60 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if defaultRetryCount < 0 || defaultRetryCount > 10 {
+		setupLog.Error(nil, "--monitor-default-retry-count must be between 0 and 10", "value", defaultRetryCount)
+		os.Exit(1)
+	}
+	if ledgerNamespace == "" {
+		ledgerNamespace = operatorNamespace()
+	}
+	if enableIngressWatcher && !slices.Contains(monitoringv1alpha1.Intervals, ingressWatcherInterval) {
+		setupLog.Error(nil, "--ingress-watcher-interval must be one of 30s, 1m, 2m, 5m or 10m", "value", ingressWatcherInterval)
+		os.Exit(1)
+	}
+	switch monitoringv1alpha1.DeletionPolicy(defaultDeletionPolicy) {
+	case monitoringv1alpha1.DeletionPolicyDelete, monitoringv1alpha1.DeletionPolicyRetain:
+	default:
+		setupLog.Error(nil, "--monitor-default-deletion-policy must be Delete or Retain", "value", defaultDeletionPolicy)
+		os.Exit(1)
+	}
+	if expanded := controller.ExpandNameTemplate(nameTemplate, "c", "ns", "n"); expanded == "" || strings.Contains(expanded, "{{") {
+		setupLog.Error(nil, "--monitor-name-template must be non-empty and only use the {{cluster}}, {{namespace}} "+
+			"and {{name}} placeholders", "value", nameTemplate)
+		os.Exit(1)
+	}
+	if caBundleFile != "" {
+		caBundle, err := os.ReadFile(caBundleFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read --upbot-ca-bundle")
+			os.Exit(1)
+		}
+		apiOpts.CABundle = string(caBundle)
+	}
+	if apiRateLimit > 0 {
+		if apiRateBurst < 1 {
+			setupLog.Error(nil, "--upbot-rate-burst must be at least 1", "value", apiRateBurst)
+			os.Exit(1)
+		}
+		apiOpts.RateLimiter = rate.NewLimiter(rate.Limit(apiRateLimit), apiRateBurst)
+	}
+	upbotConfig, err := upbot.NewConfiguration(apiOpts)
+	if err != nil {
+		setupLog.Error(err, "invalid Upbot API client settings")
+		os.Exit(1)
+	}
+	if statusPollBatchSize < 0 {
+		setupLog.Error(nil, "--status-poll-batch-size can't be negative", "value", statusPollBatchSize)
+		os.Exit(1)
+	}
+	if tokenReloadInterval <= 0 {
+		setupLog.Error(nil, "--upbot-token-reload-interval must be positive", "value", tokenReloadInterval)
+		os.Exit(1)
+	}
+	if enableOrphanGC && orphanGCInterval <= 0 {
+		setupLog.Error(nil, "--orphan-gc-interval must be positive", "value", orphanGCInterval)
+		os.Exit(1)
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -193,6 +346,9 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "6f4bd5b0.upbot.app",
+		// Secrets are read straight from the API server instead of caching
+		// every Secret of the cluster.
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -210,36 +366,146 @@ func main() {
 		os.Exit(1)
 	}
 
-	token := os.Getenv("UPBOT_TOKEN")
-	if token == "" {
-		setupLog.Error(nil, "UPBOT_TOKEN not set")
-		os.Exit(1)
+	// The default account is used by Monitors without spec.accountRef. Its
+	// token is validated before use and reloaded when it is rotated.
+	clients := upbot.NewClientCache(apiOpts)
+	var apiClient *upbot.Client
+	var tokenReloader *upbot.TokenReloader
+	var tokenSource upbot.TokenSource
+	switch {
+	case tokenFile != "":
+		tokenSource = upbot.FileTokenSource(tokenFile)
+	case tokenSecret != "":
+		namespace, name, ok := strings.Cut(tokenSecret, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "--upbot-token-secret must be 'namespace/name'", "value", tokenSecret)
+			os.Exit(1)
+		}
+		tokenSource = upbot.SecretTokenSource(mgr.GetAPIReader(),
+			types.NamespacedName{Namespace: namespace, Name: name}, tokenSecretKey)
+	case os.Getenv("UPBOT_TOKEN") != "":
+		tokenSource = upbot.StaticTokenSource(os.Getenv("UPBOT_TOKEN"))
+	default:
+		setupLog.Info("No Upbot token configured, only Monitors with spec.accountRef will be synced")
 	}
-
-	cfg := upbot.NewConfiguration()
-	cfg.AddDefaultHeader("Authorization", fmt.Sprintf("Bearer %s", token))
-	apiClient := upbot.NewAPIClient(cfg)
-	if err != nil {
-		setupLog.Error(err, "unable to create Upbot API client")
-		os.Exit(1)
+	if tokenSource != nil {
+		apiClient = upbot.NewClient(upbotsdk.NewAPIClient(upbotConfig))
+		tokenReloader = upbot.NewTokenReloader(apiClient, tokenSource, tokenReloadInterval)
+		if err := tokenReloader.Reload(context.Background()); err != nil {
+			// Not fatal: the reloader keeps trying and readyz reports the failure.
+			setupLog.Error(err, "unable to load the Upbot token")
+		}
+		if err := mgr.Add(tokenReloader); err != nil {
+			setupLog.Error(err, "unable to add the Upbot token reloader")
+			os.Exit(1)
+		}
 	}
 
-	if err := (&controller.MonitorReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		ApiClient: apiClient,
-	}).SetupWithManager(mgr); err != nil {
+	if clusterID == "" {
+		clusterID, err = controller.DefaultClusterID(context.Background(), mgr.GetAPIReader())
+		if err != nil {
+			setupLog.Error(err, "unable to determine the cluster ID, set --cluster-id")
+			os.Exit(1)
+		}
+	}
+	setupLog.Info("Naming Upbot monitors with the cluster ID", "clusterID", clusterID)
+
+	// The listings of the accounts are shared by the reconciler, the status
+	// poller and the check results metrics.
+	states := upbot.NewStateCache()
+	ledger := &controller.MonitorLedger{
+		Reader: mgr.GetAPIReader(),
+		Writer: mgr.GetClient(),
+		Key:    types.NamespacedName{Namespace: ledgerNamespace, Name: controller.DefaultLedgerName},
+	}
+	monitorReconciler := &controller.MonitorReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		ApiClient:   apiClient,
+		Clients:     clients,
+		Recorder:    mgr.GetEventRecorderFor("monitor-controller"),
+		MinInterval: minMonitorInterval,
+		MaxInterval: maxMonitorInterval,
+
+		Ledger: ledger,
+		States: states,
+
+		DefaultRetryCount: int32(defaultRetryCount),
+		ResyncPeriod:      resyncPeriod,
+		ClusterID:         clusterID,
+		NameTemplate:      nameTemplate,
+
+		DefaultDeletionPolicy: monitoringv1alpha1.DeletionPolicy(defaultDeletionPolicy),
+	}
+	if err := monitorReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Monitor")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err := webhookmonitoringv1alpha1.SetupMonitorWebhookWithManager(mgr, &webhookmonitoringv1alpha1.MonitorCustomValidator{
+			MinInterval: minMonitorInterval,
+			MaxInterval: maxMonitorInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Monitor")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	// The check results come from the listings taken by the status poller and
+	// the reconciler.
+	// States are refreshed by the status poller, or by the drift checks when it
+	// is disabled; those missing three refreshes in a row are stale.
+	checkResultsMaxAge := 3 * statusPollInterval
+	if statusPollInterval <= 0 {
+		checkResultsMaxAge = 3 * resyncPeriod
+	}
+	ctrlmetrics.Registry.MustRegister(
+		&controller.ManagedMonitorsCollector{Reader: mgr.GetClient()},
+		&controller.CheckResultsCollector{Reader: mgr.GetClient(), States: states, MaxAge: checkResultsMaxAge},
+	)
+	if statusPollInterval > 0 {
+		setupLog.Info("Enabling the monitor status poller", "interval", statusPollInterval,
+			"batchSize", statusPollBatchSize, "batchDelay", statusPollBatchDelay)
+		if err := mgr.Add(&controller.StatusPoller{
+			Client:     mgr.GetClient(),
+			Reconciler: monitorReconciler,
+			States:     states,
+			Interval:   statusPollInterval,
+			BatchSize:  statusPollBatchSize,
+			BatchDelay: statusPollBatchDelay,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the monitor status poller")
+			os.Exit(1)
+		}
+	}
+
+	if enableOrphanGC {
+		setupLog.Info("Enabling orphaned monitor garbage collection",
+			"interval", orphanGCInterval, "gracePeriod", orphanGCGracePeriod, "dryRun", orphanGCDryRun)
+		if err := mgr.Add(&controller.OrphanCollector{
+			Client:      mgr.GetClient(),
+			Reconciler:  monitorReconciler,
+			Ledger:      ledger,
+			Interval:    orphanGCInterval,
+			GracePeriod: orphanGCGracePeriod,
+			DryRun:      orphanGCDryRun,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the orphaned monitor garbage collection")
+			os.Exit(1)
+		}
+	}
+
 	if enableIngressWatcher {
 		setupLog.Info("Enabling Ingress Watcher controller", "interval", ingressWatcherInterval)
 		if err := (&controller.IngressWatcherReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ingresswatcher-controller"),
 			Interval: ingressWatcherInterval,
+
+			MinInterval: minMonitorInterval,
+			MaxInterval: maxMonitorInterval,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "IngressWatcher")
 			os.Exit(1)
@@ -268,7 +534,11 @@ func main() {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	readyz := healthz.Ping
+	if tokenReloader != nil {
+		readyz = tokenReloader.Check
+	}
+	if err := mgr.AddReadyzCheck("readyz", readyz); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
@@ -279,3 +549,13 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// operatorNamespace returns the namespace the operator runs in, or "default"
+// when it runs outside of a cluster.
+func operatorNamespace() string {
+	namespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil || len(bytes.TrimSpace(namespace)) == 0 {
+		return "default"
+	}
+	return string(bytes.TrimSpace(namespace))
+}
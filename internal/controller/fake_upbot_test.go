@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/upbothq/upbot-go-sdk"
+
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// fakeUpbot serves the monitor endpoints of the Upbot API from memory.
+type fakeUpbot struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	monitors []upbot.Monitor
+	nextID   int
+	// created, updated and deleted record the IDs of the monitors each call
+	// was made for.
+	created []string
+	updated []string
+	deleted []string
+}
+
+// newFakeUpbot starts a fakeUpbot holding the given monitors.
+func newFakeUpbot(monitors ...upbot.Monitor) *fakeUpbot {
+	f := &fakeUpbot{monitors: monitors}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	return f
+}
+
+// client returns an Upbot client sending its requests to f.
+func (f *fakeUpbot) client() *upbot.Client {
+	cfg := sdk.NewConfiguration()
+	cfg.Servers = sdk.ServerConfigurations{{URL: f.URL}}
+	api := upbot.NewClient(sdk.NewAPIClient(cfg))
+	api.SetToken("token")
+	return api
+}
+
+// calls returns copies of the IDs recorded by each kind of call.
+func (f *fakeUpbot) calls() (created, updated, deleted []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.created), slices.Clone(f.updated), slices.Clone(f.deleted)
+}
+
+func (f *fakeUpbot) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/monitors/")
+	index := slices.IndexFunc(f.monitors, func(monitor upbot.Monitor) bool { return monitor.ID == id })
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/monitors":
+		// Everything fits on the first page.
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": f.monitors, "links": map[string]any{"next": nil}})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/monitors":
+		f.nextID++
+		monitor := upbot.Monitor{ID: "m" + strconv.Itoa(f.nextID), Status: upbot.StatusUnknown}
+		if !decodeMonitorRequest(r, &monitor) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		f.monitors = append(f.monitors, monitor)
+		f.created = append(f.created, monitor.ID)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": monitor.ID})
+	case index < 0:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`"Monitor does not exist."`))
+	case r.Method == http.MethodPut:
+		if !decodeMonitorRequest(r, &f.monitors[index]) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		f.updated = append(f.updated, id)
+	case r.Method == http.MethodDelete:
+		f.monitors = slices.Delete(f.monitors, index, index+1)
+		f.deleted = append(f.deleted, id)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// decodeMonitorRequest applies the create or update request of r to monitor.
+func decodeMonitorRequest(r *http.Request, monitor *upbot.Monitor) bool {
+	var request upbot.MonitorRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return false
+	}
+	interval, err := strconv.Atoi(request.Interval)
+	if err != nil {
+		return false
+	}
+	monitor.Name = request.Name
+	monitor.Type = request.Type
+	monitor.Target = request.Target
+	monitor.Interval = int32(interval)
+	monitor.RetryCount = request.RetryCount
+	return true
+}
@@ -2,25 +2,53 @@ package controller
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
 	"golang.org/x/net/context"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// Reasons of the events emitted by the IngressWatcherReconciler.
+const (
+	reasonMonitorCreated      = "MonitorCreated"
+	reasonMonitorUpdated      = "MonitorUpdated"
+	reasonMonitorDeleted      = "MonitorDeleted"
+	reasonMonitorCreateFailed = "MonitorCreateFailed"
+	reasonMonitorUpdateFailed = "MonitorUpdateFailed"
+	reasonMonitorDeleteFailed = "MonitorDeleteFailed"
+	reasonInvalidAnnotation   = "InvalidAnnotation"
+)
+
+// defaultIngressMonitorInterval is used when neither the annotation nor the
+// --ingress-watcher-interval flag set an interval.
+const defaultIngressMonitorInterval = 30 * time.Second
+
 type IngressWatcherReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
-	Interval string
+	Recorder record.EventRecorder
+	Interval time.Duration
+
+	// MinInterval and MaxInterval bound the upbot.app/interval annotation like
+	// spec.interval; zero disables the bound.
+	MinInterval time.Duration
+	MaxInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=monitoring.upbot.app,resources=monitors,verbs=get;list;watch;create;update;patch;delete
 
 func (r *IngressWatcherReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -44,7 +72,7 @@ func (r *IngressWatcherReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if disabled, exists := ingress.Annotations["upbot.app/monitor"]; exists && (disabled == "false" || disabled == "disabled") {
 		logger.Info("Monitoring disabled for this ingress via annotation", "ingress", ingress.Name)
 		// Check if there's an existing monitor that should be cleaned up
-		return r.handleMonitorCleanupForDisabledIngress(ctx, req.NamespacedName)
+		return r.handleMonitorCleanupForDisabledIngress(ctx, &ingress)
 	}
 
 	// Check if the Monitor already exists, if not create a new one
@@ -73,16 +101,12 @@ func (r *IngressWatcherReconciler) createMonitorFromIngress(ctx context.Context,
 	target, err := r.getTargetFromIngress(ingress)
 	if err != nil {
 		logger.Error(err, "Failed to get target from Ingress", "ingress", ingress.Name, "namespace", ingress.Namespace)
+		r.Recorder.Eventf(ingress, corev1.EventTypeWarning, reasonMonitorCreateFailed, "Can't monitor the Ingress: %v", err)
 		return ctrl.Result{}, err
 	}
 
-	// Check for custom interval annotation first, then fall back to global setting
-	interval := r.Interval
-	if customInterval, exists := ingress.Annotations["upbot.app/interval"]; exists && customInterval != "" {
-		interval = customInterval
-	} else if interval == "" {
-		interval = "30" // default fallback
-	}
+	interval := r.getIntervalFromIngress(ctx, ingress)
+	retryCount := r.getRetryFromIngress(ctx, ingress)
 
 	monitor := &monitoringv1alpha1.Monitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -98,9 +122,10 @@ func (r *IngressWatcherReconciler) createMonitorFromIngress(ctx context.Context,
 			},
 		},
 		Spec: monitoringv1alpha1.MonitorSpec{
-			Type:     "http",
-			Target:   target,
-			Interval: interval,
+			Type:       monitoringv1alpha1.MonitorTypeHTTP,
+			Target:     target,
+			Interval:   monitoringv1alpha1.Interval{Duration: interval},
+			RetryCount: retryCount,
 		},
 	}
 
@@ -111,9 +136,11 @@ func (r *IngressWatcherReconciler) createMonitorFromIngress(ctx context.Context,
 
 	if err := r.Create(ctx, monitor); err != nil {
 		logger.Error(err, "Failed to create Monitor", "monitor", monitor.Name, "namespace", monitor.Namespace)
+		r.Recorder.Eventf(ingress, corev1.EventTypeWarning, reasonMonitorCreateFailed, "Failed to create Monitor %s: %v", monitor.Name, err)
 		return ctrl.Result{}, err
 	}
 	logger.Info("Successfully created Monitor", "monitor", monitor.Name, "namespace", monitor.Namespace)
+	r.Recorder.Eventf(ingress, corev1.EventTypeNormal, reasonMonitorCreated, "Created Monitor %s for %s", monitor.Name, target)
 
 	return ctrl.Result{}, nil
 }
@@ -135,20 +162,15 @@ func (r *IngressWatcherReconciler) updateMonitorIfNeeded(ctx context.Context, mo
 	expectedTarget, err := r.getTargetFromIngress(ingress)
 	if err != nil {
 		logger.Error(err, "Failed to get target from Ingress", "ingress", ingress.Name)
+		r.Recorder.Eventf(ingress, corev1.EventTypeWarning, reasonMonitorUpdateFailed, "Can't monitor the Ingress: %v", err)
 		return ctrl.Result{}, err
 	}
 
 	logger.Info("Target comparison", "monitor", monitor.Name, "current", monitor.Spec.Target, "expected", expectedTarget)
 
-	// Get the expected interval (check for custom annotation first)
-	expectedInterval := r.Interval
-	if customInterval, exists := ingress.Annotations["upbot.app/interval"]; exists && customInterval != "" {
-		expectedInterval = customInterval
-	} else if expectedInterval == "" {
-		expectedInterval = "30" // default fallback
-	}
+	expectedInterval := r.getIntervalFromIngress(ctx, ingress)
 
-	logger.Info("Interval comparison", "monitor", monitor.Name, "current", monitor.Spec.Interval, "expected", expectedInterval)
+	logger.Info("Interval comparison", "monitor", monitor.Name, "current", monitor.Spec.Interval.Duration, "expected", expectedInterval)
 
 	// Check if target needs update
 	if monitor.Spec.Target != expectedTarget {
@@ -158,16 +180,24 @@ func (r *IngressWatcherReconciler) updateMonitorIfNeeded(ctx context.Context, mo
 	}
 
 	// Check if interval needs update
-	if monitor.Spec.Interval != expectedInterval {
-		logger.Info("Interval mismatch, updating monitor", "monitor", monitor.Name, "current", monitor.Spec.Interval, "expected", expectedInterval)
-		monitor.Spec.Interval = expectedInterval
+	if monitor.Spec.Interval.Duration != expectedInterval {
+		logger.Info("Interval mismatch, updating monitor", "monitor", monitor.Name, "current", monitor.Spec.Interval.Duration, "expected", expectedInterval)
+		monitor.Spec.Interval = monitoringv1alpha1.Interval{Duration: expectedInterval}
+		needsUpdate = true
+	}
+
+	// Check if retry settings need update
+	expectedRetryCount := r.getRetryFromIngress(ctx, ingress)
+	if !ptr.Equal(monitor.Spec.RetryCount, expectedRetryCount) {
+		logger.Info("Retry count mismatch, updating monitor", "monitor", monitor.Name)
+		monitor.Spec.RetryCount = expectedRetryCount
 		needsUpdate = true
 	}
 
 	// Check if type needs update
-	if monitor.Spec.Type != "http" {
-		logger.Info("Type mismatch, updating monitor", "monitor", monitor.Name, "current", monitor.Spec.Type, "expected", "http")
-		monitor.Spec.Type = "http"
+	if monitor.Spec.Type != monitoringv1alpha1.MonitorTypeHTTP {
+		logger.Info("Type mismatch, updating monitor", "monitor", monitor.Name, "current", monitor.Spec.Type, "expected", monitoringv1alpha1.MonitorTypeHTTP)
+		monitor.Spec.Type = monitoringv1alpha1.MonitorTypeHTTP
 		needsUpdate = true
 	}
 
@@ -175,9 +205,11 @@ func (r *IngressWatcherReconciler) updateMonitorIfNeeded(ctx context.Context, mo
 		logger.Info("Updating monitor", "monitor", monitor.Name, "needsUpdate", needsUpdate)
 		if err := r.Update(ctx, monitor); err != nil {
 			logger.Error(err, "Failed to update Monitor", "monitor", monitor.Name)
+			r.Recorder.Eventf(ingress, corev1.EventTypeWarning, reasonMonitorUpdateFailed, "Failed to update Monitor %s: %v", monitor.Name, err)
 			return ctrl.Result{}, err
 		}
 		logger.Info("Successfully updated Monitor", "monitor", monitor.Name)
+		r.Recorder.Eventf(ingress, corev1.EventTypeNormal, reasonMonitorUpdated, "Updated Monitor %s to follow the Ingress", monitor.Name)
 	} else {
 		logger.Info("Monitor is up to date", "monitor", monitor.Name)
 	}
@@ -218,17 +250,22 @@ func (r *IngressWatcherReconciler) handleIngressDeletion(ctx context.Context, na
 
 	// Delete the monitor
 	logger.Info("Deleting monitor for deleted ingress", "monitor", monitor.Name, "ingress", namespacedName)
+	// The Ingress is gone, so the events go to the Monitor.
 	if err := r.Delete(ctx, &monitor); err != nil {
 		logger.Error(err, "Failed to delete monitor", "monitor", monitor.Name)
+		r.Recorder.Eventf(&monitor, corev1.EventTypeWarning, reasonMonitorDeleteFailed,
+			"Failed to delete the Monitor of deleted Ingress %s: %v", namespacedName.Name, err)
 		return ctrl.Result{}, err
 	}
+	r.Recorder.Eventf(&monitor, corev1.EventTypeNormal, reasonMonitorDeleted, "Deleting the Monitor of deleted Ingress %s", namespacedName.Name)
 
 	logger.Info("Successfully deleted monitor for deleted ingress", "monitor", monitor.Name, "ingress", namespacedName)
 	return ctrl.Result{}, nil
 }
 
-func (r *IngressWatcherReconciler) handleMonitorCleanupForDisabledIngress(ctx context.Context, namespacedName client.ObjectKey) (ctrl.Result, error) {
+func (r *IngressWatcherReconciler) handleMonitorCleanupForDisabledIngress(ctx context.Context, ingress *networkingv1.Ingress) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
+	namespacedName := client.ObjectKeyFromObject(ingress)
 
 	// Try to find the monitor associated with this ingress
 	var monitor monitoringv1alpha1.Monitor
@@ -255,8 +292,11 @@ func (r *IngressWatcherReconciler) handleMonitorCleanupForDisabledIngress(ctx co
 	logger.Info("Deleting monitor for disabled ingress", "monitor", monitor.Name, "ingress", namespacedName)
 	if err := r.Delete(ctx, &monitor); err != nil {
 		logger.Error(err, "Failed to delete monitor for disabled ingress", "monitor", monitor.Name)
+		r.Recorder.Eventf(ingress, corev1.EventTypeWarning, reasonMonitorDeleteFailed, "Failed to delete Monitor %s: %v", monitor.Name, err)
 		return ctrl.Result{}, err
 	}
+	r.Recorder.Eventf(ingress, corev1.EventTypeNormal, reasonMonitorDeleted,
+		"Deleted Monitor %s because monitoring is disabled by the upbot.app/monitor annotation", monitor.Name)
 
 	logger.Info("Successfully deleted monitor for disabled ingress", "monitor", monitor.Name, "ingress", namespacedName)
 	return ctrl.Result{}, nil
@@ -296,6 +336,65 @@ func (r *IngressWatcherReconciler) getTargetFromIngress(ingress *networkingv1.In
 	return target, nil
 }
 
+// getIntervalFromIngress returns the interval from the upbot.app/interval
+// annotation, falling back to the global setting and then to the default.
+func (r *IngressWatcherReconciler) getIntervalFromIngress(ctx context.Context, ingress *networkingv1.Ingress) time.Duration {
+	logger := log.FromContext(ctx)
+
+	if customInterval, exists := ingress.Annotations["upbot.app/interval"]; exists && customInterval != "" {
+		interval, err := parseInterval(customInterval)
+		switch {
+		case err != nil:
+		case !slices.Contains(monitoringv1alpha1.Intervals, interval):
+			err = fmt.Errorf("interval must be one of 30s, 1m, 2m, 5m or 10m")
+		case r.MinInterval > 0 && interval < r.MinInterval:
+			err = fmt.Errorf("interval must be at least %s", r.MinInterval)
+		case r.MaxInterval > 0 && interval > r.MaxInterval:
+			err = fmt.Errorf("interval must be at most %s", r.MaxInterval)
+		default:
+			return interval
+		}
+		logger.Error(err, "Ignoring invalid interval annotation", "ingress", ingress.Name, "value", customInterval)
+		r.Recorder.Eventf(ingress, corev1.EventTypeWarning, reasonInvalidAnnotation,
+			"Ignoring upbot.app/interval %q: %v", customInterval, err)
+	}
+
+	if r.Interval > 0 {
+		return r.Interval
+	}
+	return defaultIngressMonitorInterval
+}
+
+// getRetryFromIngress returns the retry count from the upbot.app/retry-count
+// annotation. An unset or invalid annotation returns nil so that the operator
+// default applies.
+func (r *IngressWatcherReconciler) getRetryFromIngress(ctx context.Context, ingress *networkingv1.Ingress) *int32 {
+	logger := log.FromContext(ctx)
+
+	value, exists := ingress.Annotations["upbot.app/retry-count"]
+	if !exists || value == "" {
+		return nil
+	}
+	count, err := strconv.ParseInt(value, 10, 32)
+	if err == nil && (count < 0 || count > 10) {
+		err = fmt.Errorf("retry count must be between 0 and 10")
+	}
+	if err != nil {
+		logger.Error(err, "Ignoring invalid retry-count annotation", "ingress", ingress.Name, "value", value)
+		return nil
+	}
+	return ptr.To(int32(count))
+}
+
+// parseInterval parses a duration such as "30s" or "5m". A bare number is
+// interpreted as seconds to keep older annotations working.
+func parseInterval(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(value)
+}
+
 func (r *IngressWatcherReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&networkingv1.Ingress{}).
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("IngressWatcher", func() {
+	It("ignores interval annotations outside the configured bounds", func() {
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &IngressWatcherReconciler{
+			Recorder:    recorder,
+			Interval:    time.Minute,
+			MinInterval: time.Minute,
+			MaxInterval: 5 * time.Minute,
+		}
+		ingress := func(interval string) *networkingv1.Ingress {
+			return &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+				Name:        "shop",
+				Annotations: map[string]string{"upbot.app/interval": interval},
+			}}
+		}
+
+		Expect(reconciler.getIntervalFromIngress(context.Background(), ingress("2m"))).To(Equal(2 * time.Minute))
+		Expect(recorder.Events).To(BeEmpty())
+
+		Expect(reconciler.getIntervalFromIngress(context.Background(), ingress("30s"))).To(Equal(time.Minute))
+		Expect(recorder.Events).To(Receive(ContainSubstring("must be at least 1m0s")))
+		Expect(reconciler.getIntervalFromIngress(context.Background(), ingress("10m"))).To(Equal(time.Minute))
+		Expect(recorder.Events).To(Receive(ContainSubstring("must be at most 5m0s")))
+		Expect(reconciler.getIntervalFromIngress(context.Background(), ingress("45s"))).To(Equal(time.Minute))
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonInvalidAnnotation)))
+	})
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+var apiRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "upbot_api_retries_total",
+	Help: "Reconciles of Monitors requeued after a failed Upbot API call, by cause: " +
+		"RetryAfter when the API asked to wait, Transient for other retryable errors.",
+}, []string{"cause"})
+
+func init() {
+	metrics.Registry.MustRegister(apiRetriesTotal)
+}
+
+// ManagedMonitorsCollector reports the number of Monitors by type, namespace,
+// source and sync state. The Monitors are listed from the manager cache on
+// each scrape, so the gauge can't drift from the cluster state.
+type ManagedMonitorsCollector struct {
+	Reader client.Reader
+}
+
+var managedMonitorsDesc = prometheus.NewDesc("upbot_managed_monitors",
+	"Monitors managed by the operator by type, namespace, source (manual or ingress-watcher) "+
+		"and sync state (Synced, Failed or Pending).",
+	[]string{"type", "namespace", "source", "sync_state"}, nil)
+
+// Describe implements prometheus.Collector.
+func (c *ManagedMonitorsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedMonitorsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *ManagedMonitorsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var monitors monitoringv1alpha1.MonitorList
+	if err := c.Reader.List(ctx, &monitors); err != nil {
+		logf.Log.WithName("metrics").Error(err, "Failed to list Monitors")
+		ch <- prometheus.NewInvalidMetric(managedMonitorsDesc, err)
+		return
+	}
+
+	type key struct{ monitorType, namespace, source, syncState string }
+	counts := map[key]int{}
+	for _, monitor := range monitors.Items {
+		counts[key{string(monitor.Spec.Type), monitor.Namespace, monitorSource(&monitor), syncState(&monitor)}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(managedMonitorsDesc, prometheus.GaugeValue, float64(count),
+			k.monitorType, k.namespace, k.source, k.syncState)
+	}
+}
+
+// monitorSource tells whether the Monitor was created by hand or by the
+// IngressWatcherReconciler.
+func monitorSource(monitor *monitoringv1alpha1.Monitor) string {
+	if monitor.Labels["upbot.app/source"] == "ingress-watcher" {
+		return "ingress-watcher"
+	}
+	return "manual"
+}
+
+// syncState summarizes the Synced condition of the Monitor.
+func syncState(monitor *monitoringv1alpha1.Monitor) string {
+	synced := meta.FindStatusCondition(monitor.Status.Conditions, monitoringv1alpha1.ConditionSynced)
+	switch {
+	case synced == nil:
+		return "Pending"
+	case synced.Status == metav1.ConditionTrue:
+		return "Synced"
+	default:
+		return "Failed"
+	}
+}
+
+// CheckResultsCollector exports the state of each Monitor, as last read from
+// Upbot. The Upbot API only reports whether the target is up, not the
+// response times or uptime of the checks. Scrapes only read States and the
+// manager cache, never the Upbot API.
+type CheckResultsCollector struct {
+	Reader client.Reader
+	States *upbot.StateCache
+	// MaxAge drops the states fetched longer ago, e.g. while the Upbot API
+	// can't be reached, rather than exporting them as current. 0 keeps them.
+	MaxAge time.Duration
+}
+
+var (
+	checkResultLabels = []string{"namespace", "name", "type", "target"}
+
+	monitorUpDesc = prometheus.NewDesc("upbot_monitor_up",
+		"Whether Upbot reports the target of the Monitor as up (1) or down (0).", checkResultLabels, nil)
+	monitorStateFetchedDesc = prometheus.NewDesc("upbot_monitor_state_fetched_timestamp_seconds",
+		"Unix time the state of the Monitor was last read from Upbot.", checkResultLabels, nil)
+)
+
+// Describe implements prometheus.Collector.
+func (c *CheckResultsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- monitorUpDesc
+	ch <- monitorStateFetchedDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *CheckResultsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var monitors monitoringv1alpha1.MonitorList
+	if err := c.Reader.List(ctx, &monitors); err != nil {
+		logf.Log.WithName("metrics").Error(err, "Failed to list Monitors")
+		ch <- prometheus.NewInvalidMetric(monitorUpDesc, err)
+		return
+	}
+
+	for _, monitor := range monitors.Items {
+		if monitor.Status.ExternalID == "" {
+			continue
+		}
+		remote, fetchedAt, ok := c.States.Get(monitorAccountKey(&monitor), monitor.Status.ExternalID)
+		if !ok || (c.MaxAge > 0 && time.Since(fetchedAt) > c.MaxAge) {
+			continue
+		}
+		labels := []string{monitor.Namespace, monitor.Name, string(monitor.Spec.Type), targetLabel(monitor.Spec.Target)}
+
+		ch <- prometheus.MustNewConstMetric(monitorStateFetchedDesc, prometheus.GaugeValue,
+			float64(fetchedAt.Unix()), labels...)
+
+		switch remote.Status {
+		case upbot.StatusOnline:
+			ch <- prometheus.MustNewConstMetric(monitorUpDesc, prometheus.GaugeValue, 1, labels...)
+		case upbot.StatusOffline:
+			ch <- prometheus.MustNewConstMetric(monitorUpDesc, prometheus.GaugeValue, 0, labels...)
+		}
+	}
+}
+
+// targetLabel returns the target without the credentials, query and fragment
+// a URL can carry, which must not end up in the metrics.
+func targetLabel(target string) string {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		// Ping targets are bare hostnames or IPs.
+		return target
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
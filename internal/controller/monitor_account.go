@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// accountRefIndexKey indexes Monitors by the account they refer to, see accountKey.
+const accountRefIndexKey = ".spec.accountRef"
+
+// accountKey identifies an account in the client cache and the accountRef index.
+func accountKey(kind monitoringv1alpha1.AccountKind, namespace, name string) string {
+	if kind == monitoringv1alpha1.AccountKindClusterUpbotAccount {
+		return string(kind) + "/" + name
+	}
+	return string(monitoringv1alpha1.AccountKindUpbotAccount) + "/" + namespace + "/" + name
+}
+
+// monitorAccountKey returns the accountKey of the account of monitor, or "" for
+// the default account.
+func monitorAccountKey(monitor *monitoringv1alpha1.Monitor) string {
+	ref := monitor.Spec.AccountRef
+	if ref == nil {
+		return ""
+	}
+	return accountKey(ref.Kind, monitor.Namespace, ref.Name)
+}
+
+// apiClientFor returns the Upbot client of the account the monitor refers to,
+// or the default client when spec.accountRef is unset.
+func (r *MonitorReconciler) apiClientFor(ctx context.Context, monitor *monitoringv1alpha1.Monitor) (*upbot.Client, error) {
+	ref := monitor.Spec.AccountRef
+	if ref == nil {
+		if r.ApiClient == nil {
+			return nil, &specError{reason: reasonAccountNotFound,
+				err: errors.New("spec.accountRef is not set and the operator has no default Upbot token")}
+		}
+		return r.ApiClient, nil
+	}
+
+	var secretNamespace string
+	var selector corev1.SecretKeySelector
+	var settings monitoringv1alpha1.APISettings
+	switch ref.Kind {
+	case monitoringv1alpha1.AccountKindClusterUpbotAccount:
+		var account monitoringv1alpha1.ClusterUpbotAccount
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name}, &account); err != nil {
+			return nil, accountError(err, ref)
+		}
+		secretNamespace = account.Spec.TokenSecretRef.Namespace
+		selector = corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: account.Spec.TokenSecretRef.Name},
+			Key:                  account.Spec.TokenSecretRef.Key,
+		}
+		settings = account.Spec.APISettings
+	default:
+		var account monitoringv1alpha1.UpbotAccount
+		if err := r.Get(ctx, types.NamespacedName{Namespace: monitor.Namespace, Name: ref.Name}, &account); err != nil {
+			return nil, accountError(err, ref)
+		}
+		secretNamespace = account.Namespace
+		selector = account.Spec.TokenSecretRef
+		settings = monitoringv1alpha1.APISettings{ClientSettings: account.Spec.ClientSettings}
+	}
+
+	token, err := r.resolveSecretKey(ctx, secretNamespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, &specError{reason: reasonAccountNotFound,
+			err: fmt.Errorf("the token of %s %s is empty", ref.Kind, ref.Name)}
+	}
+	api, err := r.Clients.Get(monitorAccountKey(monitor), token, apiOptions(settings))
+	if err != nil {
+		return nil, &specError{reason: reasonInvalidAccount, err: fmt.Errorf("%s %s: %w", ref.Kind, ref.Name, err)}
+	}
+	return api, nil
+}
+
+// apiOptions converts the API settings of an account to client options.
+func apiOptions(settings monitoringv1alpha1.APISettings) upbot.Options {
+	opts := upbot.Options{
+		BaseURL:   settings.BaseURL,
+		ProxyURL:  settings.ProxyURL,
+		CABundle:  settings.CABundle,
+		UserAgent: settings.UserAgent,
+	}
+	if settings.Timeout != nil {
+		opts.Timeout = settings.Timeout.Duration
+	}
+	return opts
+}
+
+// apiClientForAccount returns the Upbot client of the account with the given
+// accountKey, "" being the default account. The last client of an account
+// that no longer exists is returned when there is one.
+func (r *MonitorReconciler) apiClientForAccount(ctx context.Context, key string) (*upbot.Client, error) {
+	// apiClientFor only needs the account reference of a Monitor.
+	monitor := &monitoringv1alpha1.Monitor{}
+	if key != "" {
+		parts := strings.Split(key, "/")
+		switch {
+		case len(parts) == 2 && parts[0] == string(monitoringv1alpha1.AccountKindClusterUpbotAccount):
+			monitor.Spec.AccountRef = &monitoringv1alpha1.AccountReference{Kind: monitoringv1alpha1.AccountKindClusterUpbotAccount, Name: parts[1]}
+		case len(parts) == 3 && parts[0] == string(monitoringv1alpha1.AccountKindUpbotAccount):
+			monitor.Namespace = parts[1]
+			monitor.Spec.AccountRef = &monitoringv1alpha1.AccountReference{Kind: monitoringv1alpha1.AccountKindUpbotAccount, Name: parts[2]}
+		default:
+			return nil, fmt.Errorf("invalid account %q", key)
+		}
+	}
+
+	api, err := r.apiClientFor(ctx, monitor)
+	if err != nil {
+		if cached, ok := r.cachedClientFor(monitor, err); ok {
+			return cached, nil
+		}
+		return nil, err
+	}
+	return api, nil
+}
+
+// cachedClientFor returns the last client of the account of monitor when err
+// reports that the account or its token Secret no longer exists.
+func (r *MonitorReconciler) cachedClientFor(monitor *monitoringv1alpha1.Monitor, err error) (*upbot.Client, bool) {
+	var specErr *specError
+	if r.Clients == nil || monitor.Spec.AccountRef == nil || !errors.As(err, &specErr) ||
+		(specErr.reason != reasonAccountNotFound && specErr.reason != reasonSecretNotFound) {
+		return nil, false
+	}
+	return r.Clients.Cached(monitorAccountKey(monitor))
+}
+
+// forgetUnusedClient drops the cached client of the account with the given
+// key once no Monitor but the one with the given UID uses it.
+func (r *MonitorReconciler) forgetUnusedClient(ctx context.Context, key string, except types.UID) {
+	if r.Clients == nil || key == "" {
+		return
+	}
+	var monitors monitoringv1alpha1.MonitorList
+	if err := r.List(ctx, &monitors, client.MatchingFields{accountRefIndexKey: key}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Monitors using account", "account", key)
+		return
+	}
+	for _, monitor := range monitors.Items {
+		if monitor.UID != except {
+			return
+		}
+	}
+	r.Clients.Forget(key)
+}
+
+// accountError wraps a failure to read the account of a Monitor.
+func accountError(err error, ref *monitoringv1alpha1.AccountReference) error {
+	if apierrors.IsNotFound(err) {
+		return &specError{reason: reasonAccountNotFound, err: fmt.Errorf("%s %q not found", ref.Kind, ref.Name)}
+	}
+	return err
+}
+
+// findMonitorsForAccount enqueues the Monitors using an UpbotAccount or a
+// ClusterUpbotAccount.
+func (r *MonitorReconciler) findMonitorsForAccount(ctx context.Context, account client.Object) []reconcile.Request {
+	kind := monitoringv1alpha1.AccountKindUpbotAccount
+	opts := []client.ListOption{client.InNamespace(account.GetNamespace())}
+	if _, ok := account.(*monitoringv1alpha1.ClusterUpbotAccount); ok {
+		kind = monitoringv1alpha1.AccountKindClusterUpbotAccount
+		opts = nil
+	}
+	key := accountKey(kind, account.GetNamespace(), account.GetName())
+
+	var monitors monitoringv1alpha1.MonitorList
+	if err := r.List(ctx, &monitors, append(opts, client.MatchingFields{accountRefIndexKey: key})...); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Monitors using account", "account", key)
+		return nil
+	}
+
+	// The client of an unused account is rebuilt on demand, while the client
+	// of a deleted account is kept for its Monitors to delete their remote
+	// monitor, see handleDeletion.
+	if len(monitors.Items) == 0 && r.Clients != nil {
+		r.Clients.Forget(key)
+	}
+
+	requests := make([]reconcile.Request, 0, len(monitors.Items))
+	for _, monitor := range monitors.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+	}
+	return requests
+}
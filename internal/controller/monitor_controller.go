@@ -18,30 +18,118 @@ package controller
 
 import (
 	"context"
-	"net/http"
-
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
-	"github.com/upbothq/upbot-go-sdk"
+	"github.com/upbothq/operator/internal/upbot"
 )
 
 const monitorFinalizer = "monitoring.upbot.app/finalizer"
 
+// Reasons used for the conditions and events reported on a Monitor.
+const (
+	reasonCreated      = "Created"
+	reasonUpdated      = "Updated"
+	reasonCreateFailed = "CreateFailed"
+	reasonUpdateFailed = "UpdateFailed"
+	reasonDeleting     = "Deleting"
+	reasonDeleted      = "Deleted"
+	reasonDeleteFailed = "DeleteFailed"
+	reasonPending      = "Pending"
+	reasonInvalidSpec  = "InvalidSpec"
+
+	reasonSecretNotFound    = "SecretNotFound"
+	reasonSecretsResolved   = "SecretsResolved"
+	reasonNoSecretsReferred = "NoSecretsReferenced"
+
+	reasonAccountNotFound = "AccountNotFound"
+	reasonInvalidAccount  = "InvalidAccount"
+
+	reasonAdopted        = "Adopted"
+	reasonAdoptionFailed = "AdoptionFailed"
+
+	reasonRetained = "Retained"
+
+	reasonRemoteMissing = "RemoteMissing"
+	reasonRecreating    = "Recreating"
+
+	reasonInSync         = "InSync"
+	reasonDriftDetected  = "DriftDetected"
+	reasonDriftCorrected = "DriftCorrected"
+)
+
+// secretRefIndexKey indexes Monitors by the names of the Secrets they reference.
+const secretRefIndexKey = ".spec.http.secretRefs"
+
+// externalIDIndexKey indexes Monitors by the ID of their Upbot monitor.
+const externalIDIndexKey = ".status.externalID"
+
 // MonitorReconciler reconciles a Monitor object
 type MonitorReconciler struct {
 	client.Client
-	Scheme    *runtime.Scheme
-	ApiClient *upbot.APIClient
+	Scheme *runtime.Scheme
+	// ApiClient is the client of the default account, used by Monitors without
+	// spec.accountRef. It is nil when the operator has no default token.
+	ApiClient *upbot.Client
+	// Clients caches the clients of the accounts referenced by spec.accountRef.
+	Clients  *upbot.ClientCache
+	Recorder record.EventRecorder
+	// Ledger records the remote monitors managed by the Monitors. It can be nil.
+	Ledger *MonitorLedger
+	// States holds the listings of the accounts, shared with the StatusPoller.
+	// The remote monitors are read from it, see remoteMonitors.
+	States    *upbot.StateCache
+	listingMu sync.Mutex
+
+	// MinInterval and MaxInterval bound spec.interval; zero disables the bound.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// DefaultRetryCount applies to Monitors that don't set spec.retryCount.
+	DefaultRetryCount int32
+
+	// ClusterID fills the {{cluster}} placeholder of NameTemplate.
+	ClusterID string
+
+	// NameTemplate names the remote monitors of Monitors without
+	// spec.displayName, see ExpandNameTemplate. Defaults to DefaultNameTemplate.
+	NameTemplate string
+
+	// DefaultDeletionPolicy applies to Monitors that don't set spec.deletionPolicy.
+	DefaultDeletionPolicy monitoringv1alpha1.DeletionPolicy
+
+	// ResyncPeriod is how often the remote monitor is compared with the spec to
+	// detect changes made in Upbot; zero disables drift detection.
+	ResyncPeriod time.Duration
 }
 
 // +kubebuilder:rbac:groups=monitoring.upbot.app,resources=monitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.upbot.app,resources=monitors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=monitoring.upbot.app,resources=monitors/finalizers,verbs=update
+// +kubebuilder:rbac:groups=monitoring.upbot.app,resources=upbotaccounts;clusterupbotaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -79,95 +167,350 @@ func (r *MonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, nil
 	}
 
-	return r.handleCreateOrUpdate(ctx, &monitor)
+	api, err := r.apiClientFor(ctx, &monitor)
+	if err != nil {
+		return r.handleBuildError(ctx, &monitor, err)
+	}
+	return r.handleCreateOrUpdate(ctx, api, &monitor)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexMonitors(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&monitoringv1alpha1.Monitor{}).
+		// Status writes, including those of the StatusPoller, would otherwise
+		// trigger a reconcile each.
+		For(&monitoringv1alpha1.Monitor{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{}))).
+		// Only the metadata of Secrets is cached: their values are read from
+		// the API server when a Monitor references them.
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findMonitorsForSecret),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Watches(&monitoringv1alpha1.UpbotAccount{},
+			handler.EnqueueRequestsFromMapFunc(r.findMonitorsForAccount)).
+		Watches(&monitoringv1alpha1.ClusterUpbotAccount{},
+			handler.EnqueueRequestsFromMapFunc(r.findMonitorsForAccount)).
 		Named("monitor").
 		Complete(r)
 }
 
-func (r *MonitorReconciler) handleCreateOrUpdate(ctx context.Context, monitor *monitoringv1alpha1.Monitor) (ctrl.Result, error) {
+// indexMonitors registers the field indexes Monitors are listed by.
+func indexMonitors(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &monitoringv1alpha1.Monitor{}, secretRefIndexKey,
+		func(obj client.Object) []string {
+			return referencedSecrets(obj.(*monitoringv1alpha1.Monitor))
+		}); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &monitoringv1alpha1.Monitor{}, accountRefIndexKey,
+		func(obj client.Object) []string {
+			if key := monitorAccountKey(obj.(*monitoringv1alpha1.Monitor)); key != "" {
+				return []string{key}
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &monitoringv1alpha1.Monitor{}, externalIDIndexKey,
+		func(obj client.Object) []string {
+			if id := obj.(*monitoringv1alpha1.Monitor).Status.ExternalID; id != "" {
+				return []string{id}
+			}
+			return nil
+		}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// findMonitorsForSecret enqueues the Monitors referencing a Secret, so that
+// rotated credentials are pushed to Upbot.
+func (r *MonitorReconciler) findMonitorsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	var monitors monitoringv1alpha1.MonitorList
+	if err := r.List(ctx, &monitors,
+		client.InNamespace(secret.GetNamespace()),
+		client.MatchingFields{secretRefIndexKey: secret.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Monitors referencing Secret", "secret", secret.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(monitors.Items))
+	for _, monitor := range monitors.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+	}
+	return requests
+}
+
+func (r *MonitorReconciler) handleCreateOrUpdate(ctx context.Context, api *upbot.Client, monitor *monitoringv1alpha1.Monitor) (ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
 
 	// Check if monitor already exists in Upbot (has ExternalID)
 	if monitor.Status.ExternalID != "" {
 		logger.Info("Monitor already exists in Upbot", "externalID", monitor.Status.ExternalID)
-		return r.handleUpdate(ctx, monitor)
+		// Monitors synced before the ledger existed are recorded on their way.
+		r.recordRemote(ctx, monitor, monitor.Status.ExternalID)
+		return r.handleUpdate(ctx, api, monitor)
 	}
 
-	// Monitor doesn't exist in Upbot, create it
-	logger.Info("Creating monitor in Upbot", "name", monitor.Name)
+	newMonitor, hash, err := r.buildMonitorRequest(ctx, monitor)
+	if err != nil {
+		return r.handleBuildError(ctx, monitor, err)
+	}
+	r.markSecretsResolved(monitor)
 
-	val := int32(0)
-	newMonitor := upbot.StoreANewlyCreatedResourceInStorageRequest{
-		Name:       &monitor.Name,
-		Type:       monitor.Spec.Type,
-		Target:     &monitor.Spec.Target,
-		Interval:   &monitor.Spec.Interval,
-		RetryCount: *upbot.NewNullableInt32(&val),
+	existingID, adopted, err := r.findExistingMonitor(ctx, api, monitor, newMonitor)
+	if err != nil {
+		var specErr *specError
+		if errors.As(err, &specErr) {
+			return r.handleBuildError(ctx, monitor, err)
+		}
+		logger.Error(err, "Failed to look up monitor in Upbot")
+		return r.apiFailed(ctx, monitor, reasonCreateFailed, err)
+	}
+	if existingID != "" && adopted {
+		if err := r.checkNotManaged(ctx, monitor, existingID); err != nil {
+			return r.handleBuildError(ctx, monitor, err)
+		}
+	}
+	if existingID != "" {
+		logger.Info("Taking over existing monitor in Upbot", "externalID", existingID, "adopted", adopted)
+		r.recordRemote(ctx, monitor, existingID)
+		monitor.Status.ExternalID = existingID
+		if err := r.patchStatus(ctx, monitor); err != nil {
+			logger.Error(err, "Failed to record the external ID of the monitor")
+			return ctrl.Result{}, err
+		}
+		if adopted {
+			r.Recorder.Eventf(monitor, corev1.EventTypeNormal, reasonAdopted, "Adopted existing Upbot monitor %s", existingID)
+		}
+		return r.handleUpdate(ctx, api, monitor)
 	}
 
-	// Call API to create monitor
-	req := r.ApiClient.MonitorManagementAPI.StoreANewlyCreatedResourceInStorage(ctx)
-	resp, _, err := req.StoreANewlyCreatedResourceInStorageRequest(newMonitor).Execute()
+	// Monitor doesn't exist in Upbot, create it
+	logger.Info("Creating monitor in Upbot", "name", monitor.Name)
+
+	id, err := api.CreateMonitor(ctx, newMonitor)
 	if err != nil {
 		logger.Error(err, "Failed to create monitor in Upbot")
-		return ctrl.Result{}, err
+		return r.apiFailed(ctx, monitor, reasonCreateFailed, err)
 	}
 
-	// Update the status with the external ID
-	if resp != nil && resp.Id != nil {
-		monitor.Status.ExternalID = *resp.Id
-		if err := r.Status().Update(ctx, monitor); err != nil {
-			logger.Error(err, "Failed to update Monitor status with external ID")
-			return ctrl.Result{}, err
-		}
-		logger.Info("Created monitor in Upbot and updated status", "externalID", *resp.Id)
+	// Record the ID in the ledger, then in the status. Losing the status write
+	// makes the next reconcile fall back to the ledger, so retry it before giving up.
+	r.recordRemote(ctx, monitor, id)
+	monitor.Status.ExternalID = id
+	monitor.Status.AppliedHash = hash
+	r.markSynced(monitor, reasonCreated, "Monitor created in Upbot")
+	r.markDrift(monitor, nil, false)
+	if err := r.patchStatus(ctx, monitor); err != nil {
+		logger.Error(err, "Failed to update Monitor status with external ID")
+		return ctrl.Result{}, err
 	}
+	logger.Info("Created monitor in Upbot and updated status", "externalID", id)
+	r.Recorder.Eventf(monitor, corev1.EventTypeNormal, reasonCreated, "Created Upbot monitor %s", id)
 
-	return ctrl.Result{}, nil
+	return r.resyncResult(monitor), nil
 }
 
-func (r *MonitorReconciler) handleUpdate(ctx context.Context, monitor *monitoringv1alpha1.Monitor) (ctrl.Result, error) {
+func (r *MonitorReconciler) handleUpdate(ctx context.Context, api *upbot.Client, monitor *monitoringv1alpha1.Monitor) (ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
 
-	// Perform optimistic update since there's no direct "get specific monitor" method in the SDK
-	logger.Info("Updating monitor in Upbot", "externalID", monitor.Status.ExternalID)
+	updateRequest, hash, err := r.buildMonitorRequest(ctx, monitor)
+	if err != nil {
+		return r.handleBuildError(ctx, monitor, err)
+	}
+	r.markSecretsResolved(monitor)
+
+	// Every status write triggers another reconcile, so only call the API when
+	// the payload differs from the one last applied successfully, or when the
+	// periodic drift check finds that the monitor was changed in Upbot.
+	var drifted []string
+	if synced := meta.FindStatusCondition(monitor.Status.Conditions, monitoringv1alpha1.ConditionSynced); synced != nil &&
+		synced.Status == metav1.ConditionTrue && hash == monitor.Status.AppliedHash {
+		if !r.driftCheckDue(monitor) {
+			logger.V(1).Info("Monitor is up to date in Upbot, skipping update", "externalID", monitor.Status.ExternalID)
+			r.markSynced(monitor, synced.Reason, synced.Message)
+			return r.resyncResult(monitor), r.updateStatus(ctx, monitor, nil)
+		}
 
-	val := int32(0)
-	updateRequest := upbot.UpdateTheSpecifiedResourceInStorageRequest{
-		Name:       &monitor.Name,
-		Type:       &monitor.Spec.Type,
-		Target:     *upbot.NewNullableString(&monitor.Spec.Target),
-		Interval:   &monitor.Spec.Interval,
-		RetryCount: *upbot.NewNullableInt32(&val),
+		// The listing must not predate the last update of the monitor, whose
+		// time is only recorded to the second.
+		var notBefore time.Time
+		if last := monitor.Status.LastDriftCheckTime; last != nil {
+			notBefore = last.Add(time.Second)
+		}
+		remote, err := r.getRemoteMonitor(ctx, api, monitorAccountKey(monitor), monitor.Status.ExternalID, notBefore)
+		if err != nil && !upbot.IsNotFound(err) {
+			logger.Error(err, "Failed to read monitor from Upbot for drift detection", "externalID", monitor.Status.ExternalID)
+			return retryResult(err)
+		}
+		// A monitor missing in Upbot is left to the update below to report.
+		if err == nil {
+			drifted = driftedFields(remote, updateRequest)
+			if len(drifted) == 0 || monitor.Spec.DriftPolicy == monitoringv1alpha1.DriftPolicyReport {
+				if len(drifted) > 0 {
+					logger.Info("Monitor drifted in Upbot", "externalID", monitor.Status.ExternalID, "fields", drifted)
+					r.Recorder.Eventf(monitor, corev1.EventTypeWarning, reasonDriftDetected,
+						"Upbot monitor %s was changed outside the operator: %s", monitor.Status.ExternalID, strings.Join(drifted, ", "))
+				}
+				r.markDrift(monitor, drifted, false)
+				r.markSynced(monitor, synced.Reason, synced.Message)
+				return r.resyncResult(monitor), r.updateStatus(ctx, monitor, nil)
+			}
+			logger.Info("Correcting drift in Upbot", "externalID", monitor.Status.ExternalID, "fields", drifted)
+		}
 	}
 
-	req := r.ApiClient.MonitorManagementAPI.UpdateTheSpecifiedResourceInStorage(ctx, monitor.Status.ExternalID)
-	_, err := req.UpdateTheSpecifiedResourceInStorageRequest(updateRequest).Execute()
+	// Perform optimistic update since there's no direct "get specific monitor" method in the SDK
+	logger.Info("Updating monitor in Upbot", "externalID", monitor.Status.ExternalID)
+
+	err = api.UpdateMonitor(ctx, monitor.Status.ExternalID, updateRequest)
 	if err != nil {
 		logger.Error(err, "Failed to update monitor in Upbot", "externalID", monitor.Status.ExternalID)
 
-		// Check if monitor was deleted externally by trying to parse the error
-		// This is a simplified approach - in production you might want more robust error handling
-		if httpErr, ok := err.(*upbot.GenericOpenAPIError); ok {
-			// If we can't update, it might be because the monitor was deleted externally
-			// For now, we'll log the error and continue
-			logger.Info("Update failed, monitor might have been deleted externally", "error", httpErr.Error())
-			// Optionally clear the external ID and recreate:
-			// monitor.Status.ExternalID = ""
-			// return ctrl.Result{Requeue: true}, r.Status().Update(ctx, monitor)
+		if upbot.IsNotFound(err) {
+			return r.handleRemoteMissing(ctx, api, monitor)
 		}
 
-		return ctrl.Result{}, err
+		return r.apiFailed(ctx, monitor, reasonUpdateFailed, err)
 	}
 
 	logger.Info("Successfully updated monitor in Upbot", "externalID", monitor.Status.ExternalID)
-	return ctrl.Result{}, nil
+	if len(drifted) > 0 {
+		r.Recorder.Eventf(monitor, corev1.EventTypeNormal, reasonDriftCorrected,
+			"Overwrote fields of Upbot monitor %s changed outside the operator: %s", monitor.Status.ExternalID, strings.Join(drifted, ", "))
+	} else {
+		r.Recorder.Eventf(monitor, corev1.EventTypeNormal, reasonUpdated, "Updated Upbot monitor %s", monitor.Status.ExternalID)
+	}
+	monitor.Status.AppliedHash = hash
+	r.markSynced(monitor, reasonUpdated, "Monitor updated in Upbot")
+	r.markDrift(monitor, drifted, true)
+	return r.resyncResult(monitor), r.updateStatus(ctx, monitor, nil)
+}
+
+// handleRemoteMissing deals with a monitor that was deleted in Upbot, by
+// recreating it unless spec.recreateOnMissing is false.
+func (r *MonitorReconciler) handleRemoteMissing(ctx context.Context, api *upbot.Client, monitor *monitoringv1alpha1.Monitor) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+	externalID := monitor.Status.ExternalID
+
+	if monitor.Spec.RecreateOnMissing != nil && !*monitor.Spec.RecreateOnMissing {
+		logger.Info("Monitor was deleted in Upbot and recreateOnMissing is false", "externalID", externalID)
+		err := fmt.Errorf("monitor %s no longer exists in Upbot and spec.recreateOnMissing is false", externalID)
+		r.Recorder.Event(monitor, corev1.EventTypeWarning, reasonRemoteMissing, err.Error())
+		// Retrying can't bring the monitor back, wait for the spec to change.
+		r.markFailed(monitor, reasonRemoteMissing, err)
+		return ctrl.Result{}, r.updateStatus(ctx, monitor, nil)
+	}
+
+	logger.Info("Monitor was deleted in Upbot, recreating it", "externalID", externalID)
+	r.Recorder.Eventf(monitor, corev1.EventTypeWarning, reasonRecreating,
+		"Monitor %s no longer exists in Upbot, recreating it", externalID)
+	r.forgetRemote(ctx, externalID)
+	monitor.Status.ExternalID = ""
+	monitor.Status.AppliedHash = ""
+	return r.handleCreateOrUpdate(ctx, api, monitor)
+}
+
+// findExistingMonitor returns the ID of the remote monitor the Monitor should
+// manage instead of creating a new one, or "" when there is none. adopted is
+// true when the monitor was not created by this Monitor.
+func (r *MonitorReconciler) findExistingMonitor(ctx context.Context, api *upbot.Client, monitor *monitoringv1alpha1.Monitor, request upbot.MonitorRequest) (id string, adopted bool, err error) {
+	if externalID := monitor.Spec.ExternalID; externalID != "" {
+		remote, err := r.getRemoteMonitor(ctx, api, monitorAccountKey(monitor), externalID, time.Time{})
+		if upbot.IsNotFound(err) {
+			return "", false, &specError{reason: reasonAdoptionFailed,
+				err: fmt.Errorf("monitor %s referenced by spec.externalID doesn't exist in Upbot", externalID)}
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return remote.ID, true, nil
+	}
+
+	// A previous reconcile may have created the monitor without managing to
+	// record its ID in the status. Look it up in the ledger before creating a
+	// duplicate.
+	if r.Ledger != nil {
+		id, err := r.Ledger.Find(ctx, monitor.UID)
+		if err != nil {
+			return "", false, fmt.Errorf("reading the monitor ledger: %w", err)
+		}
+		if id != "" {
+			_, err := r.getRemoteMonitor(ctx, api, monitorAccountKey(monitor), id, time.Time{})
+			if err == nil {
+				return id, false, nil
+			}
+			if !upbot.IsNotFound(err) {
+				return "", false, err
+			}
+			r.forgetRemote(ctx, id)
+		}
+	}
+
+	if monitor.Spec.AdoptByName {
+		matches, err := r.findRemoteMonitorsByName(ctx, api, monitorAccountKey(monitor), request.Name)
+		if err != nil {
+			return "", false, err
+		}
+		switch len(matches) {
+		case 0:
+		case 1:
+			return matches[0].ID, true, nil
+		default:
+			return "", false, &specError{reason: reasonAdoptionFailed,
+				err: fmt.Errorf("%d monitors named %q exist in Upbot, set spec.externalID to pick one", len(matches), request.Name)}
+		}
+	}
+	return "", false, nil
+}
+
+// checkNotManaged fails with AdoptionFailed when the remote monitor with the
+// given ID is already managed by another Monitor, which adopting it would
+// make the two Monitors fight over.
+func (r *MonitorReconciler) checkNotManaged(ctx context.Context, monitor *monitoringv1alpha1.Monitor, id string) error {
+	var monitors monitoringv1alpha1.MonitorList
+	if err := r.List(ctx, &monitors, client.MatchingFields{externalIDIndexKey: id}); err != nil {
+		return err
+	}
+	for _, other := range monitors.Items {
+		if other.UID != monitor.UID {
+			return &specError{reason: reasonAdoptionFailed,
+				err: fmt.Errorf("monitor %s is already managed by Monitor %s/%s", id, other.Namespace, other.Name)}
+		}
+	}
+	return nil
+}
+
+// recordRemote records in the ledger that the remote monitor with the given ID
+// is managed by monitor. Failures are only logged: the status of the Monitor
+// remains the primary record of the ID.
+func (r *MonitorReconciler) recordRemote(ctx context.Context, monitor *monitoringv1alpha1.Monitor, id string) {
+	if r.Ledger == nil {
+		return
+	}
+	if err := r.Ledger.Record(ctx, id, newLedgerEntry(monitor)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to record the monitor in the ledger", "externalID", id)
+	}
+}
+
+// forgetRemote removes the remote monitor with the given ID from the ledger.
+// A stale entry is only a candidate for the OrphanCollector, which checks that
+// its Monitor is gone, so failures are only logged.
+func (r *MonitorReconciler) forgetRemote(ctx context.Context, id string) {
+	if r.Ledger == nil {
+		return
+	}
+	if err := r.Ledger.Remove(ctx, id); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to remove the monitor from the ledger", "externalID", id)
+	}
 }
 
 func (r *MonitorReconciler) handleDeletion(ctx context.Context, monitor *monitoringv1alpha1.Monitor) (ctrl.Result, error) {
@@ -179,21 +522,58 @@ func (r *MonitorReconciler) handleDeletion(ctx context.Context, monitor *monitor
 		return ctrl.Result{}, nil
 	}
 
+	// Keep the remote monitor when asked to. Upbot is left untouched, the
+	// monitor is only removed from the ledger so that it isn't collected.
+	if monitor.Status.ExternalID != "" && r.deletionPolicy(monitor) == monitoringv1alpha1.DeletionPolicyRetain {
+		logger.Info("Retaining monitor in Upbot", "externalID", monitor.Status.ExternalID)
+		r.forgetRemote(ctx, monitor.Status.ExternalID)
+		r.Recorder.Eventf(monitor, corev1.EventTypeNormal, reasonRetained,
+			"Kept monitor %s in Upbot, it is no longer managed by the operator", monitor.Status.ExternalID)
+	}
+
 	// Delete from external system if ExternalID exists
-	if monitor.Status.ExternalID != "" {
-		logger.Info("Deleting monitor from Upbot", "externalID", monitor.Status.ExternalID)
+	if monitor.Status.ExternalID != "" && r.deletionPolicy(monitor) == monitoringv1alpha1.DeletionPolicyDelete {
+		// When the account or its token Secret was deleted first, the last
+		// client of the account still works. Without one, the finalizer stays
+		// until the account is usable again, otherwise the remote monitor
+		// would leak.
+		api, err := r.apiClientFor(ctx, monitor)
+		if err != nil {
+			cached, ok := r.cachedClientFor(monitor, err)
+			if !ok {
+				return r.handleBuildError(ctx, monitor, err)
+			}
+			logger.Info("Account of the monitor is unavailable, deleting with its last known client", "reason", err.Error())
+			api = cached
+		}
 
-		_, httpResp, err := r.ApiClient.MonitorManagementAPI.DeleteASpecificMonitor(ctx, monitor.Status.ExternalID).Execute()
+		logger.Info("Deleting monitor from Upbot", "externalID", monitor.Status.ExternalID)
+		meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             reasonDeleting,
+			Message:            "Monitor is being deleted from Upbot",
+			ObservedGeneration: monitor.Generation,
+		})
+
+		err = api.DeleteMonitor(ctx, monitor.Status.ExternalID)
 		if err != nil {
 			// Check if it's a 404 error (monitor already deleted)
-			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			if upbot.IsNotFound(err) {
 				logger.Info("Monitor already deleted in Upbot", "externalID", monitor.Status.ExternalID)
 			} else {
 				logger.Error(err, "Failed to delete monitor in Upbot", "externalID", monitor.Status.ExternalID)
-				return ctrl.Result{}, err
+				return r.apiFailed(ctx, monitor, reasonDeleteFailed, err)
 			}
 		} else {
 			logger.Info("Successfully deleted monitor from Upbot", "externalID", monitor.Status.ExternalID)
+			r.Recorder.Eventf(monitor, corev1.EventTypeNormal, reasonDeleted, "Deleted Upbot monitor %s", monitor.Status.ExternalID)
+		}
+		r.forgetRemote(ctx, monitor.Status.ExternalID)
+
+		if err := r.updateStatus(ctx, monitor, nil); err != nil {
+			logger.Error(err, "Failed to update Monitor status before removing finalizer")
+			return ctrl.Result{}, err
 		}
 	}
 
@@ -205,5 +585,199 @@ func (r *MonitorReconciler) handleDeletion(ctx context.Context, monitor *monitor
 	}
 
 	logger.Info("Removed finalizer, monitor will be deleted")
+	r.forgetUnusedClient(ctx, monitorAccountKey(monitor), monitor.UID)
 	return ctrl.Result{}, nil
 }
+
+// deletionPolicy returns the deletion policy of the monitor, falling back to
+// the operator default.
+func (r *MonitorReconciler) deletionPolicy(monitor *monitoringv1alpha1.Monitor) monitoringv1alpha1.DeletionPolicy {
+	if monitor.Spec.DeletionPolicy != "" {
+		return monitor.Spec.DeletionPolicy
+	}
+	if r.DefaultDeletionPolicy != "" {
+		return r.DefaultDeletionPolicy
+	}
+	return monitoringv1alpha1.DeletionPolicyDelete
+}
+
+// handleBuildError records why the spec can't be applied to Upbot.
+func (r *MonitorReconciler) handleBuildError(ctx context.Context, monitor *monitoringv1alpha1.Monitor, err error) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var specErr *specError
+	if !errors.As(err, &specErr) {
+		logger.Error(err, "Failed to build monitor request")
+		return ctrl.Result{}, err
+	}
+
+	// The spec or a referenced Secret has to change before we can do anything,
+	// and both trigger a reconcile, so don't requeue.
+	logger.Error(err, "Monitor spec can't be applied", "reason", specErr.reason)
+	r.Recorder.Event(monitor, corev1.EventTypeWarning, specErr.reason, err.Error())
+	if specErr.reason == reasonSecretNotFound {
+		meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionSecretsResolved,
+			Status:             metav1.ConditionFalse,
+			Reason:             reasonSecretNotFound,
+			Message:            err.Error(),
+			ObservedGeneration: monitor.Generation,
+		})
+	}
+	r.markFailed(monitor, specErr.reason, err)
+	return ctrl.Result{}, r.updateStatus(ctx, monitor, nil)
+}
+
+// apiFailed records a failed Upbot API call on the monitor status and in a
+// warning event, and returns when to retry it, see retryResult. Synced tells
+// which call failed, and Ready why, in the words of the API when it explained
+// the failure. Repeated failures are aggregated into a single event with a
+// count by the event recorder.
+func (r *MonitorReconciler) apiFailed(ctx context.Context, monitor *monitoringv1alpha1.Monitor, reason string, err error) (ctrl.Result, error) {
+	r.markFailed(monitor, reason, err)
+	r.Recorder.Event(monitor, corev1.EventTypeWarning, reason, err.Error())
+	var apiErr *upbot.APIError
+	if errors.As(err, &apiErr) && apiErr.Kind() != "" {
+		meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(apiErr.Kind()),
+			Message:            apiErr.Message(),
+			ObservedGeneration: monitor.Generation,
+		})
+	}
+	result, retryErr := retryResult(err)
+	return result, r.updateStatus(ctx, monitor, retryErr)
+}
+
+// retryResult returns when to retry a reconcile that failed on an Upbot API
+// call: once the delay asked by a Retry-After header elapsed, with the
+// controller backoff for other transient errors, and not until the Monitor
+// changes for permanent errors such as a rejected payload.
+func retryResult(err error) (ctrl.Result, error) {
+	if after := upbot.RetryAfter(err); after > 0 {
+		apiRetriesTotal.WithLabelValues("RetryAfter").Inc()
+		return ctrl.Result{RequeueAfter: after}, nil
+	}
+	if !upbot.IsTransient(err) {
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	}
+	apiRetriesTotal.WithLabelValues("Transient").Inc()
+	return ctrl.Result{}, err
+}
+
+// markSecretsResolved records that every Secret referenced by the spec was read.
+func (r *MonitorReconciler) markSecretsResolved(monitor *monitoringv1alpha1.Monitor) {
+	condition := metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionSecretsResolved,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonSecretsResolved,
+		Message:            "All referenced Secrets were resolved",
+		ObservedGeneration: monitor.Generation,
+	}
+	if len(referencedSecrets(monitor)) == 0 {
+		condition.Reason = reasonNoSecretsReferred
+		condition.Message = "The spec doesn't reference any Secret"
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, condition)
+}
+
+// markSynced records a successful sync with Upbot on the monitor status.
+func (r *MonitorReconciler) markSynced(monitor *monitoringv1alpha1.Monitor, reason, message string) {
+	// Only move LastSyncTime forward when the sync changed something, otherwise
+	// every status write would trigger another reconcile.
+	if monitor.Status.ObservedGeneration != monitor.Generation ||
+		!meta.IsStatusConditionTrue(monitor.Status.Conditions, monitoringv1alpha1.ConditionSynced) {
+		now := metav1.Now()
+		monitor.Status.LastSyncTime = &now
+	}
+	monitor.Status.ObservedGeneration = monitor.Generation
+	monitor.Status.LastError = ""
+
+	meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: monitor.Generation,
+	})
+	meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: monitor.Generation,
+	})
+	if meta.FindStatusCondition(monitor.Status.Conditions, monitoringv1alpha1.ConditionRemoteHealthy) == nil {
+		meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+			Type:               monitoringv1alpha1.ConditionRemoteHealthy,
+			Status:             metav1.ConditionUnknown,
+			Reason:             reasonPending,
+			Message:            "Waiting for Upbot to report the monitor state",
+			ObservedGeneration: monitor.Generation,
+		})
+	}
+}
+
+// markFailed records a failed sync with Upbot on the monitor status.
+func (r *MonitorReconciler) markFailed(monitor *monitoringv1alpha1.Monitor, reason string, err error) {
+	monitor.Status.ObservedGeneration = monitor.Generation
+	monitor.Status.LastError = err.Error()
+
+	meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionSynced,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: monitor.Generation,
+	})
+	meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: monitor.Generation,
+	})
+}
+
+// patchStatus writes the monitor status with a merge patch on top of the stored
+// object, so that a stale resourceVersion can't make it fail, and retries
+// transient errors.
+func (r *MonitorReconciler) patchStatus(ctx context.Context, monitor *monitoringv1alpha1.Monitor) error {
+	return retry.OnError(retry.DefaultBackoff, func(err error) bool {
+		return !apierrors.IsNotFound(err)
+	}, func() error {
+		var current monitoringv1alpha1.Monitor
+		if err := r.Get(ctx, client.ObjectKeyFromObject(monitor), &current); err != nil {
+			return err
+		}
+		patched := current.DeepCopy()
+		patched.Status = monitor.Status
+		if err := r.Status().Patch(ctx, patched, client.MergeFrom(&current)); err != nil {
+			return err
+		}
+		monitor.ResourceVersion = patched.ResourceVersion
+		return nil
+	})
+}
+
+// updateStatus writes the monitor status if it differs from the stored one and
+// returns reconcileErr so callers can propagate the original failure.
+func (r *MonitorReconciler) updateStatus(ctx context.Context, monitor *monitoringv1alpha1.Monitor, reconcileErr error) error {
+	logger := logf.FromContext(ctx)
+
+	var current monitoringv1alpha1.Monitor
+	if err := r.Get(ctx, client.ObjectKeyFromObject(monitor), &current); err == nil &&
+		equality.Semantic.DeepEqual(current.Status, monitor.Status) {
+		return reconcileErr
+	}
+
+	if err := r.Status().Update(ctx, monitor); err != nil {
+		logger.Error(err, "Failed to update Monitor status")
+		if reconcileErr != nil {
+			return reconcileErr
+		}
+		return err
+	}
+	return reconcileErr
+}
@@ -18,16 +18,22 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
 )
 
 var _ = Describe("Monitor Controller", func() {
@@ -51,7 +57,11 @@ var _ = Describe("Monitor Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: monitoringv1alpha1.MonitorSpec{
+						Type:     monitoringv1alpha1.MonitorTypeHTTP,
+						Target:   "https://example.com",
+						Interval: monitoringv1alpha1.Interval{Duration: time.Minute},
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -69,8 +79,9 @@ var _ = Describe("Monitor Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &MonitorReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -81,4 +92,218 @@ var _ = Describe("Monitor Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When deleting a Monitor", func() {
+		ctx := context.Background()
+		var (
+			server     *fakeUpbot
+			reconciler *MonitorReconciler
+		)
+
+		BeforeEach(func() {
+			server = newFakeUpbot()
+			DeferCleanup(server.Close)
+			reconciler = &MonitorReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				ApiClient: server.client(),
+				Recorder:  record.NewFakeRecorder(100),
+				Ledger: &MonitorLedger{
+					Reader: k8sClient,
+					Writer: k8sClient,
+					Key:    types.NamespacedName{Namespace: "default", Name: DefaultLedgerName},
+				},
+			}
+		})
+
+		// createSynced creates a Monitor and reconciles it until its remote
+		// monitor exists, returning the ID of the remote monitor.
+		createSynced := func(name string, policy monitoringv1alpha1.DeletionPolicy) string {
+			monitor := &monitoringv1alpha1.Monitor{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: monitoringv1alpha1.MonitorSpec{
+					Type:           monitoringv1alpha1.MonitorTypeHTTP,
+					Target:         "https://" + name + ".example.com",
+					Interval:       monitoringv1alpha1.Interval{Duration: time.Minute},
+					DeletionPolicy: policy,
+				},
+			}
+			Expect(k8sClient.Create(ctx, monitor)).To(Succeed())
+
+			key := client.ObjectKeyFromObject(monitor)
+			for range 2 {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+			Expect(monitor.Status.ExternalID).NotTo(BeEmpty())
+			return monitor.Status.ExternalID
+		}
+
+		// deleteMonitor deletes the Monitor and reconciles the deletion.
+		deleteMonitor := func(name string) {
+			key := types.NamespacedName{Name: name, Namespace: "default"}
+			monitor := &monitoringv1alpha1.Monitor{}
+			Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, monitor)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, key, monitor))).To(BeTrue())
+		}
+
+		It("deletes the remote monitor with the Delete policy", func() {
+			id := createSynced("delete-policy", monitoringv1alpha1.DeletionPolicyDelete)
+
+			deleteMonitor("delete-policy")
+			_, _, deleted := server.calls()
+			Expect(deleted).To(Equal([]string{id}))
+			entries, err := reconciler.Ledger.Entries(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).NotTo(HaveKey(id))
+		})
+
+		It("keeps the remote monitor with the Retain policy", func() {
+			id := createSynced("retain-policy", monitoringv1alpha1.DeletionPolicyRetain)
+
+			deleteMonitor("retain-policy")
+			_, _, deleted := server.calls()
+			Expect(deleted).To(BeEmpty())
+			// Out of the ledger, the OrphanCollector leaves it alone.
+			entries, err := reconciler.Ledger.Entries(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).NotTo(HaveKey(id))
+		})
+
+		It("falls back to the operator default policy", func() {
+			reconciler.DefaultDeletionPolicy = monitoringv1alpha1.DeletionPolicyRetain
+			createSynced("default-policy", "")
+
+			deleteMonitor("default-policy")
+			_, _, deleted := server.calls()
+			Expect(deleted).To(BeEmpty())
+		})
+	})
+
+	Context("When adopting a remote monitor", func() {
+		var (
+			ctx        context.Context
+			server     *fakeUpbot
+			recorder   *record.FakeRecorder
+			informers  cache.Cache
+			reconciler *MonitorReconciler
+		)
+
+		BeforeEach(func() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			server = newFakeUpbot(upbot.Monitor{
+				ID: "legacy-id", Name: "legacy", Type: "http", Target: "https://legacy.example.com", Interval: 300,
+			})
+			DeferCleanup(server.Close)
+
+			// The API server can't select Monitors by status.externalID, so
+			// Monitors are listed from a cache holding the indexes, as the
+			// manager does.
+			var err error
+			informers, err = cache.New(cfg, cache.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indexMonitors(ctx, informers)).To(Succeed())
+			go func() {
+				defer GinkgoRecover()
+				Expect(informers.Start(ctx)).To(Succeed())
+			}()
+			Expect(informers.WaitForCacheSync(ctx)).To(BeTrue())
+
+			recorder = record.NewFakeRecorder(100)
+			reconciler = &MonitorReconciler{
+				Client:    indexedClient{Client: k8sClient, cache: informers},
+				Scheme:    k8sClient.Scheme(),
+				ApiClient: server.client(),
+				Recorder:  recorder,
+			}
+		})
+
+		// reconcileNew creates a Monitor adopting the remote monitor with the
+		// given ID, reconciles it and returns its state.
+		reconcileNew := func(name, externalID string) *monitoringv1alpha1.Monitor {
+			monitor := &monitoringv1alpha1.Monitor{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: monitoringv1alpha1.MonitorSpec{
+					Type:       monitoringv1alpha1.MonitorTypeHTTP,
+					Target:     "https://legacy.example.com",
+					Interval:   monitoringv1alpha1.Interval{Duration: time.Minute},
+					ExternalID: externalID,
+				},
+			}
+			Expect(k8sClient.Create(ctx, monitor)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(monitor), monitor))).To(Succeed())
+				monitor.Finalizers = nil
+				Expect(client.IgnoreNotFound(k8sClient.Update(ctx, monitor))).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, monitor))).To(Succeed())
+			})
+
+			key := client.ObjectKeyFromObject(monitor)
+			for range 2 {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+			return monitor
+		}
+
+		It("manages the monitor referenced by spec.externalID instead of creating one", func() {
+			monitor := reconcileNew("adopt", "legacy-id")
+
+			Expect(monitor.Status.ExternalID).To(Equal("legacy-id"))
+			Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, monitoringv1alpha1.ConditionSynced)).To(BeTrue())
+			created, updated, _ := server.calls()
+			Expect(created).To(BeEmpty())
+			Expect(updated).To(Equal([]string{"legacy-id"}))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring(reasonAdopted)))
+		})
+
+		It("fails when spec.externalID doesn't exist in Upbot", func() {
+			monitor := reconcileNew("adopt-missing", "missing-id")
+
+			Expect(monitor.Status.ExternalID).To(BeEmpty())
+			synced := meta.FindStatusCondition(monitor.Status.Conditions, monitoringv1alpha1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(reasonAdoptionFailed))
+			created, _, _ := server.calls()
+			Expect(created).To(BeEmpty())
+		})
+
+		It("refuses to adopt a monitor already managed by another Monitor", func() {
+			first := reconcileNew("adopt-first", "legacy-id")
+			Expect(first.Status.ExternalID).To(Equal("legacy-id"))
+			Eventually(func(g Gomega) {
+				var monitors monitoringv1alpha1.MonitorList
+				g.Expect(informers.List(ctx, &monitors, client.MatchingFields{externalIDIndexKey: "legacy-id"})).To(Succeed())
+				g.Expect(monitors.Items).To(HaveLen(1))
+			}).Should(Succeed())
+
+			second := reconcileNew("adopt-second", "legacy-id")
+			Expect(second.Status.ExternalID).To(BeEmpty())
+			synced := meta.FindStatusCondition(second.Status.Conditions, monitoringv1alpha1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(reasonAdoptionFailed))
+			Expect(synced.Message).To(ContainSubstring("default/adopt-first"))
+			_, updated, _ := server.calls()
+			Expect(updated).To(Equal([]string{"legacy-id"}))
+		})
+	})
 })
+
+// indexedClient lists from an informer cache holding the field indexes of the
+// reconciler, and reads and writes everything else through the API server.
+type indexedClient struct {
+	client.Client
+	cache cache.Cache
+}
+
+func (c indexedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.cache.List(ctx, list, opts...)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// driftedFields returns the fields of the remote monitor that differ from the
+// request. Only the fields returned by the Upbot API can be compared.
+func driftedFields(remote *upbot.Monitor, request upbot.MonitorRequest) []string {
+	var fields []string
+	if remote.Name != request.Name {
+		fields = append(fields, "name")
+	}
+	if remote.Type != request.Type {
+		fields = append(fields, "type")
+	}
+	if remote.Target != request.Target {
+		fields = append(fields, "target")
+	}
+	if strconv.Itoa(int(remote.Interval)) != request.Interval {
+		fields = append(fields, "interval")
+	}
+	if remote.RetryCount != request.RetryCount {
+		fields = append(fields, "retryCount")
+	}
+	return fields
+}
+
+// driftCheckDue reports whether the resync period elapsed since the remote
+// monitor was last compared with the spec.
+func (r *MonitorReconciler) driftCheckDue(monitor *monitoringv1alpha1.Monitor) bool {
+	if r.ResyncPeriod <= 0 {
+		return false
+	}
+	last := monitor.Status.LastDriftCheckTime
+	return last == nil || time.Since(last.Time) >= r.ResyncPeriod
+}
+
+// resyncResult requeues the monitor for its next drift check.
+func (r *MonitorReconciler) resyncResult(monitor *monitoringv1alpha1.Monitor) ctrl.Result {
+	if r.ResyncPeriod <= 0 {
+		return ctrl.Result{}
+	}
+	after := r.ResyncPeriod
+	if last := monitor.Status.LastDriftCheckTime; last != nil {
+		after = max(r.ResyncPeriod-time.Since(last.Time), time.Second)
+	}
+	return ctrl.Result{RequeueAfter: after}
+}
+
+// markDrift records the outcome of a comparison with the remote monitor.
+// fields lists the drifted fields and corrected tells whether they were
+// overwritten with the spec.
+func (r *MonitorReconciler) markDrift(monitor *monitoringv1alpha1.Monitor, fields []string, corrected bool) {
+	now := metav1.Now()
+	monitor.Status.LastDriftCheckTime = &now
+
+	condition := metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionDrifted,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonInSync,
+		Message:            "Monitor in Upbot matches the spec",
+		ObservedGeneration: monitor.Generation,
+	}
+	switch {
+	case len(fields) == 0:
+	case corrected:
+		condition.Reason = reasonDriftCorrected
+		condition.Message = "Overwrote fields changed in Upbot: " + strings.Join(fields, ", ")
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonDriftDetected
+		condition.Message = "Fields changed in Upbot: " + strings.Join(fields, ", ")
+	}
+	meta.SetStatusCondition(&monitor.Status.Conditions, condition)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+var _ = Describe("driftedFields", func() {
+	request := upbot.MonitorRequest{
+		Name:       "shop",
+		Type:       "http",
+		Target:     "https://shop.example.com",
+		Interval:   "60",
+		RetryCount: 2,
+	}
+
+	It("reports nothing when the remote monitor matches the request", func() {
+		remote := &upbot.Monitor{ID: "abc", Name: "shop", Type: "http", Target: "https://shop.example.com",
+			Interval: 60, RetryCount: 2, Status: upbot.StatusOffline}
+		Expect(driftedFields(remote, request)).To(BeEmpty())
+	})
+
+	It("lists every field changed in Upbot", func() {
+		remote := &upbot.Monitor{ID: "abc", Name: "Shop", Type: "ping", Target: "shop.example.com",
+			Interval: 300, RetryCount: 0}
+		Expect(driftedFields(remote, request)).To(Equal([]string{"name", "type", "target", "interval", "retryCount"}))
+
+		remote = &upbot.Monitor{ID: "abc", Name: "shop", Type: "http", Target: "https://shop.example.com",
+			Interval: 30, RetryCount: 2}
+		Expect(driftedFields(remote, request)).To(Equal([]string{"interval"}))
+	})
+})
@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+)
+
+// DefaultLedgerName is the name of the ConfigMap of the MonitorLedger.
+const DefaultLedgerName = "upbot-operator-monitors"
+
+// MonitorLedger records in a ConfigMap the Upbot monitors managed by the
+// operator, keyed by their ID. Upbot monitors carry no metadata the operator
+// could recognize them by, so the ledger is how a monitor whose ID was lost is
+// found again, and how the OrphanCollector knows which monitors are its own.
+//
+// The ConfigMap lives in the namespace of the operator, where the leader
+// election Role already grants access to ConfigMaps.
+type MonitorLedger struct {
+	// Reader reads the ConfigMap without going through the cache, which would
+	// watch every ConfigMap of the cluster.
+	Reader client.Reader
+	Writer client.Writer
+	Key    types.NamespacedName
+
+	// recorded holds the entries known to be in the ConfigMap, so that
+	// recording an unchanged entry doesn't read the ConfigMap every time.
+	mu       sync.Mutex
+	recorded map[string]string
+}
+
+// LedgerEntry is the Monitor managing a remote monitor.
+type LedgerEntry struct {
+	// Account is the accountKey of the account of the monitor, "" for the
+	// default account.
+	Account string `json:"account,omitempty"`
+	// Monitor is the namespace/name of the Monitor.
+	Monitor string    `json:"monitor"`
+	UID     types.UID `json:"uid"`
+}
+
+// newLedgerEntry returns the entry of the remote monitor of monitor.
+func newLedgerEntry(monitor *monitoringv1alpha1.Monitor) LedgerEntry {
+	return LedgerEntry{
+		Account: monitorAccountKey(monitor),
+		Monitor: monitor.Namespace + "/" + monitor.Name,
+		UID:     monitor.UID,
+	}
+}
+
+// Entries returns the recorded monitors by ID.
+func (l *MonitorLedger) Entries(ctx context.Context) (map[string]LedgerEntry, error) {
+	var configMap corev1.ConfigMap
+	if err := l.Reader.Get(ctx, l.Key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := make(map[string]LedgerEntry, len(configMap.Data))
+	for id, value := range configMap.Data {
+		var entry LedgerEntry
+		// Unreadable entries are dropped rather than blocking the whole ledger.
+		if json.Unmarshal([]byte(value), &entry) == nil {
+			entries[id] = entry
+		}
+	}
+	return entries, nil
+}
+
+// Find returns the ID of the remote monitor recorded for the Monitor with the
+// given UID, or "" when there is none.
+func (l *MonitorLedger) Find(ctx context.Context, uid types.UID) (string, error) {
+	entries, err := l.Entries(ctx)
+	if err != nil {
+		return "", err
+	}
+	for id, entry := range entries {
+		if entry.UID == uid {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// Record records that the remote monitor with the given ID is managed by the
+// Monitor of entry.
+func (l *MonitorLedger) Record(ctx context.Context, id string, entry LedgerEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	known := l.recorded[id] == string(value)
+	l.mu.Unlock()
+	if known {
+		return nil
+	}
+
+	if err := l.modify(ctx, func(data map[string]string) bool {
+		if data[id] == string(value) {
+			return false
+		}
+		data[id] = string(value)
+		return true
+	}); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.recorded == nil {
+		l.recorded = map[string]string{}
+	}
+	l.recorded[id] = string(value)
+	return nil
+}
+
+// Remove forgets the remote monitors with the given IDs.
+func (l *MonitorLedger) Remove(ctx context.Context, ids ...string) error {
+	l.mu.Lock()
+	for _, id := range ids {
+		delete(l.recorded, id)
+	}
+	l.mu.Unlock()
+
+	return l.modify(ctx, func(data map[string]string) bool {
+		changed := false
+		for _, id := range ids {
+			if _, ok := data[id]; ok {
+				delete(data, id)
+				changed = true
+			}
+		}
+		return changed
+	})
+}
+
+// modify applies change to the data of the ConfigMap, creating it when needed.
+// change returns whether it modified the data.
+func (l *MonitorLedger) modify(ctx context.Context, change func(data map[string]string) bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var configMap corev1.ConfigMap
+		err := l.Reader.Get(ctx, l.Key, &configMap)
+		if apierrors.IsNotFound(err) {
+			configMap = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: l.Key.Namespace,
+					Name:      l.Key.Name,
+					Labels:    map[string]string{"app.kubernetes.io/managed-by": "upbot-operator"},
+				},
+				Data: map[string]string{},
+			}
+			if !change(configMap.Data) {
+				return nil
+			}
+			err = l.Writer.Create(ctx, &configMap)
+			if apierrors.IsAlreadyExists(err) {
+				// Created concurrently, retry the update.
+				return apierrors.NewConflict(corev1.Resource("configmaps"), l.Key.Name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		if !change(configMap.Data) {
+			return nil
+		}
+		return l.Writer.Update(ctx, &configMap)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MonitorLedger", func() {
+	It("records and forgets remote monitors", func() {
+		ctx := context.Background()
+		k8sClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		key := types.NamespacedName{Namespace: "upbot-system", Name: DefaultLedgerName}
+		ledger := &MonitorLedger{Reader: k8sClient, Writer: k8sClient, Key: key}
+
+		entries, err := ledger.Entries(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+
+		shop := LedgerEntry{Monitor: "default/shop", UID: "uid-shop"}
+		blog := LedgerEntry{Monitor: "blog/home", UID: "uid-blog"}
+		Expect(ledger.Record(ctx, "a", shop)).To(Succeed())
+		Expect(ledger.Record(ctx, "b", blog)).To(Succeed())
+		Expect(ledger.Record(ctx, "b", blog)).To(Succeed())
+
+		entries, err = ledger.Entries(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(Equal(map[string]LedgerEntry{"a": shop, "b": blog}))
+		id, err := ledger.Find(ctx, "uid-blog")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("b"))
+
+		Expect(ledger.Remove(ctx, "a", "missing")).To(Succeed())
+		var configMap corev1.ConfigMap
+		Expect(k8sClient.Get(ctx, key, &configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveLen(1))
+		id, err = ledger.Find(ctx, "uid-shop")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(BeEmpty())
+	})
+})
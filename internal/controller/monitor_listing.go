@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// listingMaxAge is how long a listing of the monitors of an account is reused
+// to read the remote monitors of the Monitors.
+const listingMaxAge = time.Minute
+
+// remoteMonitors returns the monitors of the account with the given key. The
+// API has no endpoint to read a single monitor, so instead of paging through
+// the listing for every Monitor, a listing younger than listingMaxAge, taken
+// by another reconcile or by the StatusPoller, is reused if it was taken after
+// notBefore. reused reports whether the listing came from States.
+func (r *MonitorReconciler) remoteMonitors(ctx context.Context, api *upbot.Client, account string, notBefore time.Time) (monitors []upbot.Monitor, reused bool, err error) {
+	r.listingMu.Lock()
+	defer r.listingMu.Unlock()
+
+	if r.States == nil {
+		r.States = upbot.NewStateCache()
+	}
+	if monitors, fetchedAt, ok := r.States.List(account); ok && fetchedAt.After(notBefore) && time.Since(fetchedAt) < listingMaxAge {
+		return monitors, true, nil
+	}
+
+	fetchedAt := time.Now()
+	monitors, err = api.ListMonitors(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	r.States.Set(account, monitors, fetchedAt)
+	return monitors, false, nil
+}
+
+// getRemoteMonitor returns the monitor of the account with the given ID, as
+// listed after notBefore, or an *upbot.APIError with status 404 when it doesn't
+// exist. A monitor missing from a reused listing may have been created since,
+// so it is only reported missing once a new listing confirms it.
+func (r *MonitorReconciler) getRemoteMonitor(ctx context.Context, api *upbot.Client, account, id string, notBefore time.Time) (*upbot.Monitor, error) {
+	for {
+		monitors, reused, err := r.remoteMonitors(ctx, api, account, notBefore)
+		if err != nil {
+			return nil, err
+		}
+		for i := range monitors {
+			if monitors[i].ID == id {
+				return &monitors[i], nil
+			}
+		}
+		if !reused {
+			return nil, &upbot.APIError{StatusCode: http.StatusNotFound, Body: []byte(fmt.Sprintf("monitor %q not found", id))}
+		}
+		notBefore = time.Now()
+	}
+}
+
+// findRemoteMonitorsByName returns the monitors of the account whose display
+// name is name.
+func (r *MonitorReconciler) findRemoteMonitorsByName(ctx context.Context, api *upbot.Client, account, name string) ([]upbot.Monitor, error) {
+	monitors, _, err := r.remoteMonitors(ctx, api, account, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	var found []upbot.Monitor
+	for _, monitor := range monitors {
+		if monitor.Name == name {
+			found = append(found, monitor)
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// specError is returned for problems that can only be fixed by changing the
+// Monitor or the objects it references, so retrying the reconcile won't help.
+type specError struct {
+	reason string
+	err    error
+}
+
+func (e *specError) Error() string { return e.err.Error() }
+
+func (e *specError) Unwrap() error { return e.err }
+
+func invalidSpec(format string, args ...any) error {
+	return &specError{reason: reasonInvalidSpec, err: fmt.Errorf(format, args...)}
+}
+
+// buildMonitorRequest maps the monitor spec to the payload sent to Upbot,
+// resolving the Secrets it references, and returns the payload along with its
+// hash for status.appliedHash.
+func (r *MonitorReconciler) buildMonitorRequest(ctx context.Context, monitor *monitoringv1alpha1.Monitor) (upbot.MonitorRequest, string, error) {
+	monitorType, err := upbotType(monitor.Spec.Type)
+	if err != nil {
+		return upbot.MonitorRequest{}, "", err
+	}
+	interval, err := r.upbotInterval(monitor)
+	if err != nil {
+		return upbot.MonitorRequest{}, "", err
+	}
+
+	request := upbot.MonitorRequest{
+		Name:       r.remoteName(monitor),
+		Type:       monitorType,
+		Target:     monitor.Spec.Target,
+		Interval:   interval,
+		RetryCount: r.DefaultRetryCount,
+	}
+	if monitor.Spec.RetryCount != nil {
+		request.RetryCount = *monitor.Spec.RetryCount
+	}
+	if request.RetryCount < 0 || request.RetryCount > 10 {
+		return upbot.MonitorRequest{}, "", invalidSpec("retryCount must be between 0 and 10, got %d", request.RetryCount)
+	}
+
+	// hashed is the payload with the Secret values left out, they are hashed
+	// by the resourceVersion of their Secret instead.
+	hashed := monitorRequestHash{Request: request}
+	secretValue := func(selector corev1.SecretKeySelector) (string, error) {
+		value, resourceVersion, err := r.readSecretKey(ctx, monitor.Namespace, selector)
+		if err != nil {
+			return "", err
+		}
+		if hashed.Secrets == nil {
+			hashed.Secrets = map[string]string{}
+		}
+		hashed.Secrets[selector.Name+"/"+selector.Key] = resourceVersion
+		return value, nil
+	}
+	if httpSpec := monitor.Spec.HTTP; httpSpec != nil && (len(httpSpec.QueryParams) > 0 || httpSpec.BasicAuth != nil) {
+		target, err := url.Parse(monitor.Spec.Target)
+		if err != nil {
+			return upbot.MonitorRequest{}, "", invalidSpec("target is not a valid URL: %v", err)
+		}
+		hashedTarget := *target
+
+		query, hashedQuery := target.Query(), target.Query()
+		for _, param := range httpSpec.QueryParams {
+			if param.ValueFrom == nil {
+				query.Set(param.Name, param.Value)
+				hashedQuery.Set(param.Name, param.Value)
+				continue
+			}
+			value, err := secretValue(param.ValueFrom.SecretKeyRef)
+			if err != nil {
+				return upbot.MonitorRequest{}, "", err
+			}
+			query.Set(param.Name, value)
+			hashedQuery.Set(param.Name, "")
+		}
+		target.RawQuery = query.Encode()
+		hashedTarget.RawQuery = hashedQuery.Encode()
+
+		if auth := httpSpec.BasicAuth; auth != nil {
+			username, err := secretValue(auth.Username)
+			if err != nil {
+				return upbot.MonitorRequest{}, "", err
+			}
+			password, err := secretValue(auth.Password)
+			if err != nil {
+				return upbot.MonitorRequest{}, "", err
+			}
+			target.User = url.UserPassword(username, password)
+			hashedTarget.User = nil
+		}
+
+		request.Target = target.String()
+		hashed.Request.Target = hashedTarget.String()
+	}
+
+	hash, err := hashed.sum()
+	if err != nil {
+		return upbot.MonitorRequest{}, "", err
+	}
+	return request, hash, nil
+}
+
+// DefaultNameTemplate names remote monitors after their Monitor.
+const DefaultNameTemplate = "{{name}}"
+
+// ExpandNameTemplate replaces the {{cluster}}, {{namespace}} and {{name}}
+// placeholders of a naming template.
+func ExpandNameTemplate(template, cluster, namespace, name string) string {
+	return strings.NewReplacer(
+		"{{cluster}}", cluster,
+		"{{namespace}}", namespace,
+		"{{name}}", name,
+	).Replace(template)
+}
+
+// remoteName returns the name of the monitor in Upbot: spec.displayName, or
+// the naming template expanded for the monitor.
+func (r *MonitorReconciler) remoteName(monitor *monitoringv1alpha1.Monitor) string {
+	if monitor.Spec.DisplayName != "" {
+		return monitor.Spec.DisplayName
+	}
+	template := r.NameTemplate
+	if template == "" {
+		template = DefaultNameTemplate
+	}
+	return ExpandNameTemplate(template, r.ClusterID, monitor.Namespace, monitor.Name)
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// DefaultClusterID returns the UID of the kube-system namespace, which is
+// stable for the lifetime of a cluster and unique across clusters.
+func DefaultClusterID(ctx context.Context, reader client.Reader) (string, error) {
+	var namespace corev1.Namespace
+	if err := reader.Get(ctx, types.NamespacedName{Name: "kube-system"}, &namespace); err != nil {
+		return "", err
+	}
+	return string(namespace.UID), nil
+}
+
+// monitorRequestHash is what status.appliedHash is computed from. The status
+// can be read by anyone who can read the Monitor, so it must not be possible to
+// recover Secret values from the hash: they are replaced by the
+// resourceVersion of their Secret, which still changes when a credential is
+// rotated.
+type monitorRequestHash struct {
+	Request upbot.MonitorRequest `json:"request"`
+	// Secrets maps the name/key of each referenced Secret key to the
+	// resourceVersion of the Secret.
+	Secrets map[string]string `json:"secrets,omitempty"`
+}
+
+// sum returns the hex encoded sha256 digest of h.
+func (h monitorRequestHash) sum() (string, error) {
+	payload, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// upbotType returns the Upbot API type of spec.type. Monitors stored before
+// the type enum was restricted can still carry types the API doesn't run.
+func upbotType(monitorType monitoringv1alpha1.MonitorType) (string, error) {
+	switch monitorType {
+	case monitoringv1alpha1.MonitorTypeHTTP, monitoringv1alpha1.MonitorTypeHTTPS:
+		return string(monitoringv1alpha1.MonitorTypeHTTP), nil
+	case monitoringv1alpha1.MonitorTypePing:
+		return string(monitoringv1alpha1.MonitorTypePing), nil
+	default:
+		return "", invalidSpec("type %q is not supported by the Upbot API, use http, https or ping", monitorType)
+	}
+}
+
+// upbotInterval checks spec.interval against the configured bounds and the
+// intervals accepted by the Upbot API, and returns it in the whole seconds
+// expected by the API.
+func (r *MonitorReconciler) upbotInterval(monitor *monitoringv1alpha1.Monitor) (string, error) {
+	interval := monitor.Spec.Interval.Duration
+	if interval <= 0 {
+		return "", invalidSpec("interval must be positive, got %s", interval)
+	}
+	if r.MinInterval > 0 && interval < r.MinInterval {
+		return "", invalidSpec("interval %s is shorter than the minimum of %s", interval, r.MinInterval)
+	}
+	if r.MaxInterval > 0 && interval > r.MaxInterval {
+		return "", invalidSpec("interval %s is longer than the maximum of %s", interval, r.MaxInterval)
+	}
+	if !slices.Contains(monitoringv1alpha1.Intervals, interval) {
+		return "", invalidSpec("interval %s is not supported by the Upbot API, use 30s, 1m, 2m, 5m or 10m", interval)
+	}
+	return strconv.FormatInt(int64(interval/time.Second), 10), nil
+}
+
+// resolveSecretKey reads a key of a Secret in the given namespace.
+func (r *MonitorReconciler) resolveSecretKey(ctx context.Context, namespace string, selector corev1.SecretKeySelector) (string, error) {
+	value, _, err := r.readSecretKey(ctx, namespace, selector)
+	return value, err
+}
+
+// readSecretKey reads a key of a Secret in the given namespace and returns it
+// with the resourceVersion of the Secret, "" when an optional Secret is missing.
+func (r *MonitorReconciler) readSecretKey(ctx context.Context, namespace string, selector corev1.SecretKeySelector) (string, string, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, &secret); err != nil {
+		if errors.IsNotFound(err) {
+			if selector.Optional != nil && *selector.Optional {
+				return "", "", nil
+			}
+			return "", "", &specError{reason: reasonSecretNotFound, err: fmt.Errorf("secret %q not found", selector.Name)}
+		}
+		return "", "", err
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		if selector.Optional != nil && *selector.Optional {
+			return "", secret.ResourceVersion, nil
+		}
+		return "", "", &specError{reason: reasonSecretNotFound, err: fmt.Errorf("key %q not found in secret %q", selector.Key, selector.Name)}
+	}
+	return string(value), secret.ResourceVersion, nil
+}
+
+// referencedSecrets returns the names of the Secrets referenced by the monitor.
+func referencedSecrets(monitor *monitoringv1alpha1.Monitor) []string {
+	httpSpec := monitor.Spec.HTTP
+	if httpSpec == nil {
+		return nil
+	}
+
+	var names []string
+	for _, param := range httpSpec.QueryParams {
+		if param.ValueFrom != nil {
+			names = append(names, param.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	if httpSpec.BasicAuth != nil {
+		names = append(names, httpSpec.BasicAuth.Username.Name, httpSpec.BasicAuth.Password.Name)
+	}
+	return names
+}
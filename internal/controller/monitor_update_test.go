@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+var _ = Describe("handleUpdate", func() {
+	var (
+		ctx        context.Context
+		k8sClient  client.Client
+		server     *fakeUpbot
+		reconciler *MonitorReconciler
+		secret     *corev1.Secret
+		key        client.ObjectKey
+	)
+
+	// update runs handleUpdate against the stored Monitor.
+	update := func() {
+		var monitor monitoringv1alpha1.Monitor
+		Expect(k8sClient.Get(ctx, key, &monitor)).To(Succeed())
+		_, err := reconciler.handleUpdate(ctx, server.client(), &monitor)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(monitoringv1alpha1.AddToScheme(scheme)).To(Succeed())
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shop-token"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t")},
+		}
+		monitor := &monitoringv1alpha1.Monitor{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shop"},
+			Spec: monitoringv1alpha1.MonitorSpec{
+				Type:     monitoringv1alpha1.MonitorTypeHTTPS,
+				Target:   "https://shop.example.com/health",
+				Interval: monitoringv1alpha1.Interval{Duration: time.Minute},
+				HTTP: &monitoringv1alpha1.HTTPOptions{QueryParams: []monitoringv1alpha1.HTTPQueryParam{{
+					Name: "token",
+					ValueFrom: &monitoringv1alpha1.SecretValueSource{SecretKeyRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "shop-token"},
+						Key:                  "token",
+					}},
+				}}},
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(secret, monitor).WithStatusSubresource(monitor).Build()
+		key = client.ObjectKeyFromObject(monitor)
+
+		server = newFakeUpbot(upbot.Monitor{ID: "shop-id", Name: "shop"})
+		DeferCleanup(server.Close)
+		reconciler = &MonitorReconciler{Client: k8sClient, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+		// The Monitor was last synced with its current spec and Secret.
+		_, hash, err := reconciler.buildMonitorRequest(ctx, monitor)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, key, monitor)).To(Succeed())
+		monitor.Status.ExternalID = "shop-id"
+		monitor.Status.AppliedHash = hash
+		meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+			Type: monitoringv1alpha1.ConditionSynced, Status: metav1.ConditionTrue, Reason: reasonUpdated,
+		})
+		Expect(k8sClient.Status().Update(ctx, monitor)).To(Succeed())
+	})
+
+	It("doesn't call Upbot while the applied hash matches", func() {
+		update()
+		update()
+
+		_, updated, _ := server.calls()
+		Expect(updated).To(BeEmpty())
+	})
+
+	It("updates the monitor once after a spec change", func() {
+		var monitor monitoringv1alpha1.Monitor
+		Expect(k8sClient.Get(ctx, key, &monitor)).To(Succeed())
+		monitor.Spec.Target = "https://shop.example.com/ready"
+		Expect(k8sClient.Update(ctx, &monitor)).To(Succeed())
+
+		update()
+		update()
+
+		_, updated, _ := server.calls()
+		Expect(updated).To(Equal([]string{"shop-id"}))
+	})
+
+	It("updates the monitor once after a Secret rotation", func() {
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		secret.Data["token"] = []byte("r0t4t3d")
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+		update()
+		update()
+
+		_, updated, _ := server.calls()
+		Expect(updated).To(Equal([]string{"shop-id"}))
+	})
+})
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// OrphanCollector periodically deletes the Upbot monitors recorded in the
+// ledger whose Monitor no longer exists, e.g. because its finalizer was removed
+// by hand or the CRD was deleted while the operator was down. Each monitor is
+// deleted through the account it was recorded with. Monitors that aren't in
+// the ledger, such as those of other clusters or retained ones, are never
+// touched.
+type OrphanCollector struct {
+	Client client.Client
+	// Reconciler resolves the Upbot account of each monitor.
+	Reconciler *MonitorReconciler
+	Ledger     *MonitorLedger
+
+	// Interval is the time between two collection passes.
+	Interval time.Duration
+	// GracePeriod is how long a remote monitor has to be seen without a
+	// Monitor before it is deleted.
+	GracePeriod time.Duration
+	// DryRun only logs the monitors that would be deleted.
+	DryRun bool
+
+	// orphanedSince records when each remote monitor was first seen without a Monitor.
+	orphanedSince map[string]time.Time
+}
+
+// NeedLeaderElection makes sure that only one replica deletes remote monitors.
+func (c *OrphanCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a collection pass every Interval until ctx is cancelled.
+func (c *OrphanCollector) Start(ctx context.Context) error {
+	logger := logf.FromContext(ctx).WithName("orphan-collector")
+	c.orphanedSince = map[string]time.Time{}
+
+	// Wait one interval before the first pass so that Monitors created while
+	// the operator was down get a chance to record their ID.
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(c.Interval):
+	}
+
+	wait.UntilWithContext(logf.IntoContext(ctx, logger), func(ctx context.Context) {
+		if err := c.collect(ctx); err != nil {
+			logger.Error(err, "Failed to collect orphaned monitors")
+		}
+	}, c.Interval)
+	return nil
+}
+
+// collect runs a single collection pass.
+func (c *OrphanCollector) collect(ctx context.Context) error {
+	logger := logf.FromContext(ctx)
+
+	entries, err := c.Ledger.Entries(ctx)
+	if err != nil {
+		return err
+	}
+
+	var monitors monitoringv1alpha1.MonitorList
+	if err := c.Client.List(ctx, &monitors); err != nil {
+		// Without the Monitor CRD, no Monitor exists: every recorded monitor
+		// is orphaned.
+		if !meta.IsNoMatchError(err) {
+			return err
+		}
+		logger.Info("The Monitor CRD isn't installed, treating every recorded monitor as orphaned")
+	}
+	knownUIDs := make(map[types.UID]bool, len(monitors.Items))
+	knownIDs := make(map[string]bool, len(monitors.Items))
+	for _, monitor := range monitors.Items {
+		knownUIDs[monitor.UID] = true
+		if monitor.Status.ExternalID != "" {
+			knownIDs[monitor.Status.ExternalID] = true
+		}
+	}
+
+	now := time.Now()
+	orphaned := map[string]time.Time{}
+	clients := map[string]*upbot.Client{}
+	for id, entry := range entries {
+		if knownIDs[id] || knownUIDs[entry.UID] {
+			continue
+		}
+
+		since, seen := c.orphanedSince[id]
+		if !seen {
+			since = now
+		}
+		orphaned[id] = since
+		if now.Sub(since) < c.GracePeriod {
+			logger.Info("Found orphaned monitor in Upbot, waiting for the grace period",
+				"externalID", id, "monitor", entry.Monitor, "orphanedSince", since)
+			continue
+		}
+
+		if c.DryRun {
+			logger.Info("Would delete orphaned monitor from Upbot (dry run)", "externalID", id, "monitor", entry.Monitor)
+			continue
+		}
+		api, resolved := clients[entry.Account]
+		if !resolved {
+			var err error
+			if api, err = c.Reconciler.apiClientForAccount(ctx, entry.Account); err != nil {
+				// The monitor stays in the ledger until its account is usable again.
+				logger.Error(err, "Unable to resolve the account of orphaned monitors", "account", entry.Account)
+			}
+			clients[entry.Account] = api
+		}
+		if api == nil {
+			continue
+		}
+		logger.Info("Deleting orphaned monitor from Upbot", "externalID", id, "monitor", entry.Monitor, "account", entry.Account)
+		if err := api.DeleteMonitor(ctx, id); err != nil && !upbot.IsNotFound(err) {
+			logger.Error(err, "Failed to delete orphaned monitor from Upbot", "externalID", id)
+			continue
+		}
+		if err := c.Ledger.Remove(ctx, id); err != nil {
+			logger.Error(err, "Failed to remove the deleted monitor from the ledger", "externalID", id)
+			continue
+		}
+		delete(orphaned, id)
+	}
+	// Forget monitors that were deleted or got a Monitor again.
+	c.orphanedSince = orphaned
+	return nil
+}
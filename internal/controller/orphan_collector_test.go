@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+var _ = Describe("OrphanCollector", func() {
+	var (
+		ctx       context.Context
+		server    *fakeUpbot
+		ledger    *MonitorLedger
+		collector *OrphanCollector
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(monitoringv1alpha1.AddToScheme(scheme)).To(Succeed())
+		shop := &monitoringv1alpha1.Monitor{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shop", UID: "uid-shop"},
+			Status:     monitoringv1alpha1.MonitorStatus{ExternalID: "shop-id"},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(shop).Build()
+
+		server = newFakeUpbot(
+			upbot.Monitor{ID: "shop-id", Name: "shop"},
+			upbot.Monitor{ID: "gone-id", Name: "gone"},
+			upbot.Monitor{ID: "foreign-id", Name: "foreign"},
+		)
+		DeferCleanup(server.Close)
+
+		ledger = &MonitorLedger{
+			Reader: k8sClient,
+			Writer: k8sClient,
+			Key:    types.NamespacedName{Namespace: "upbot-system", Name: DefaultLedgerName},
+		}
+		Expect(ledger.Record(ctx, "shop-id", LedgerEntry{Monitor: "default/shop", UID: "uid-shop"})).To(Succeed())
+		Expect(ledger.Record(ctx, "gone-id", LedgerEntry{Monitor: "default/gone", UID: "uid-gone"})).To(Succeed())
+
+		collector = &OrphanCollector{
+			Client:        k8sClient,
+			Reconciler:    &MonitorReconciler{Client: k8sClient, Scheme: scheme, ApiClient: server.client()},
+			Ledger:        ledger,
+			orphanedSince: map[string]time.Time{},
+		}
+	})
+
+	It("deletes the recorded monitors whose Monitor is gone", func() {
+		Expect(collector.collect(ctx)).To(Succeed())
+
+		_, _, deleted := server.calls()
+		Expect(deleted).To(Equal([]string{"gone-id"}))
+		entries, err := ledger.Entries(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveKey("shop-id"))
+		Expect(entries).NotTo(HaveKey("gone-id"))
+	})
+
+	It("waits for the grace period before deleting", func() {
+		collector.GracePeriod = time.Hour
+		Expect(collector.collect(ctx)).To(Succeed())
+		_, _, deleted := server.calls()
+		Expect(deleted).To(BeEmpty())
+		Expect(collector.orphanedSince).To(HaveKey("gone-id"))
+
+		collector.orphanedSince["gone-id"] = time.Now().Add(-2 * time.Hour)
+		Expect(collector.collect(ctx)).To(Succeed())
+		_, _, deleted = server.calls()
+		Expect(deleted).To(Equal([]string{"gone-id"}))
+		Expect(collector.orphanedSince).To(BeEmpty())
+	})
+
+	It("only logs in dry run mode", func() {
+		collector.DryRun = true
+		Expect(collector.collect(ctx)).To(Succeed())
+
+		_, _, deleted := server.calls()
+		Expect(deleted).To(BeEmpty())
+		entries, err := ledger.Entries(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveKey("gone-id"))
+	})
+
+	It("keeps the monitors whose account can't be resolved", func() {
+		Expect(ledger.Record(ctx, "team-id", LedgerEntry{
+			Account: "UpbotAccount/team-a/main", Monitor: "team-a/api", UID: "uid-api",
+		})).To(Succeed())
+		Expect(collector.collect(ctx)).To(Succeed())
+
+		_, _, deleted := server.calls()
+		Expect(deleted).To(Equal([]string{"gone-id"}))
+		entries, err := ledger.Entries(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveKey("team-id"))
+	})
+
+	It("treats every recorded monitor as orphaned when the Monitor CRD is missing", func() {
+		collector.Client = interceptor.NewClient(collector.Client.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*monitoringv1alpha1.MonitorList); ok {
+					return &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "monitoring.upbot.app", Kind: "Monitor"}}
+				}
+				return c.List(ctx, list, opts...)
+			},
+		})
+		Expect(collector.collect(ctx)).To(Succeed())
+
+		_, _, deleted := server.calls()
+		Expect(deleted).To(ConsistOf("shop-id", "gone-id"))
+		entries, err := ledger.Entries(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+})
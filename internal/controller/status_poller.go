@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+// Reasons of the RemoteHealthy condition.
+const (
+	reasonUp           = "Up"
+	reasonDown         = "Down"
+	reasonStateUnknown = "StateUnknown"
+)
+
+// StatusPoller periodically mirrors the state Upbot reports for each monitor,
+// whether the target is up or down, into the Monitor status.
+//
+// Each pass reads the monitors of an account with a single paginated listing,
+// whatever the number of Monitors, and keeps it in States. The Monitor
+// statuses are then written in batches to spread the load on the API server.
+//
+// Polling is the only source of state changes: the Upbot API doesn't send
+// alert or incident webhooks the operator could receive instead.
+type StatusPoller struct {
+	Client client.Client
+	// Reconciler resolves the Upbot account of each Monitor.
+	Reconciler *MonitorReconciler
+	// States receives the listing of each account.
+	States *upbot.StateCache
+
+	// Interval is the time between two passes.
+	Interval time.Duration
+	// BatchSize is the number of Monitors updated before pausing for
+	// BatchDelay. 0 updates every Monitor without pausing.
+	BatchSize  int
+	BatchDelay time.Duration
+}
+
+// NeedLeaderElection makes sure that only one replica writes the statuses.
+func (p *StatusPoller) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a pass every Interval until ctx is cancelled.
+func (p *StatusPoller) Start(ctx context.Context) error {
+	logger := logf.FromContext(ctx).WithName("status-poller")
+	wait.UntilWithContext(logf.IntoContext(ctx, logger), func(ctx context.Context) {
+		if err := p.poll(ctx); err != nil {
+			logger.Error(err, "Failed to poll monitor states")
+		}
+	}, p.Interval)
+	return nil
+}
+
+// poll runs a single pass.
+func (p *StatusPoller) poll(ctx context.Context) error {
+	logger := logf.FromContext(ctx)
+
+	var monitors monitoringv1alpha1.MonitorList
+	if err := p.Client.List(ctx, &monitors); err != nil {
+		return err
+	}
+
+	byAccount := map[string][]*monitoringv1alpha1.Monitor{}
+	for i := range monitors.Items {
+		monitor := &monitors.Items[i]
+		if monitor.Status.ExternalID == "" || !monitor.DeletionTimestamp.IsZero() {
+			continue
+		}
+		account := monitorAccountKey(monitor)
+		byAccount[account] = append(byAccount[account], monitor)
+	}
+
+	type update struct {
+		monitor *monitoringv1alpha1.Monitor
+		remote  upbot.Monitor
+	}
+	var updates []update
+	for account, accountMonitors := range byAccount {
+		// Account errors are reported on the Monitors by the reconciler.
+		api, err := p.Reconciler.apiClientFor(ctx, accountMonitors[0])
+		if err != nil {
+			logger.V(1).Info("Skipping the Monitors of an unusable account", "account", account, "error", err.Error())
+			continue
+		}
+		fetchedAt := time.Now()
+		remoteMonitors, err := api.ListMonitors(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to list monitors from Upbot", "account", account)
+			continue
+		}
+		p.States.Set(account, remoteMonitors, fetchedAt)
+
+		for _, monitor := range accountMonitors {
+			// Missing monitors are left to the reconciler to recreate or report.
+			if remote, _, ok := p.States.Get(account, monitor.Status.ExternalID); ok {
+				updates = append(updates, update{monitor: monitor, remote: remote})
+			}
+		}
+	}
+
+	for i, u := range updates {
+		if i > 0 && p.BatchSize > 0 && i%p.BatchSize == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(p.BatchDelay):
+			}
+		}
+		if err := p.updateStatus(ctx, u.monitor, u.remote); err != nil {
+			logger.Error(err, "Failed to update the state of Monitor", "monitor", client.ObjectKeyFromObject(u.monitor))
+		}
+	}
+	return nil
+}
+
+// updateStatus writes the remote state into the status of monitor when it
+// changed. A Monitor modified since it was listed is skipped until the next pass.
+func (p *StatusPoller) updateStatus(ctx context.Context, monitor *monitoringv1alpha1.Monitor, remote upbot.Monitor) error {
+	patched := monitor.DeepCopy()
+	applyRemoteState(patched, remote)
+	if equality.Semantic.DeepEqual(monitor.Status, patched.Status) {
+		return nil
+	}
+	err := p.Client.Status().Patch(ctx, patched, client.MergeFromWithOptions(monitor, client.MergeFromWithOptimisticLock{}))
+	if apierrors.IsConflict(err) {
+		return nil
+	}
+	return client.IgnoreNotFound(err)
+}
+
+// applyRemoteState copies the state reported by Upbot into the status of monitor.
+func applyRemoteState(monitor *monitoringv1alpha1.Monitor, remote upbot.Monitor) {
+	status := &monitor.Status
+	status.State = remote.Status
+
+	condition := metav1.Condition{
+		Type:               monitoringv1alpha1.ConditionRemoteHealthy,
+		Status:             metav1.ConditionUnknown,
+		Reason:             reasonStateUnknown,
+		Message:            "Upbot reports the state " + strconv.Quote(remote.Status),
+		ObservedGeneration: monitor.Generation,
+	}
+	switch remote.Status {
+	case upbot.StatusOnline:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonUp
+		condition.Message = "Upbot reports the target as up"
+	case upbot.StatusOffline:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonDown
+		condition.Message = "Upbot reports the target as down"
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+	"github.com/upbothq/operator/internal/upbot"
+)
+
+var _ = Describe("StatusPoller", func() {
+	It("mirrors the remote state into the Monitor status", func() {
+		monitor := &monitoringv1alpha1.Monitor{}
+
+		applyRemoteState(monitor, upbot.Monitor{ID: "abc", Status: upbot.StatusOffline})
+
+		Expect(monitor.Status.State).To(Equal(upbot.StatusOffline))
+		healthy := meta.FindStatusCondition(monitor.Status.Conditions, monitoringv1alpha1.ConditionRemoteHealthy)
+		Expect(healthy.Status).To(Equal(metav1.ConditionFalse))
+		Expect(healthy.Reason).To(Equal(reasonDown))
+
+		applyRemoteState(monitor, upbot.Monitor{ID: "abc", Status: upbot.StatusOnline})
+		Expect(monitor.Status.State).To(Equal(upbot.StatusOnline))
+		Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, monitoringv1alpha1.ConditionRemoteHealthy)).To(BeTrue())
+	})
+})
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"sync"
+
+	sdk "github.com/upbothq/upbot-go-sdk"
+)
+
+// ClientCache keeps one Client per account and replaces it when the token or
+// the options of the account change.
+type ClientCache struct {
+	defaults Options
+
+	mu      sync.Mutex
+	clients map[string]cachedClient
+}
+
+type cachedClient struct {
+	token  string
+	opts   Options
+	client *Client
+}
+
+// NewClientCache returns a ClientCache whose clients are built from defaults
+// merged with the options of their account.
+func NewClientCache(defaults Options) *ClientCache {
+	return &ClientCache{defaults: defaults, clients: map[string]cachedClient{}}
+}
+
+// Get returns the Client of account authenticating with token. opts override
+// the defaults of the cache.
+func (c *ClientCache) Get(account, token string, opts Options) (*Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opts = c.defaults.Merge(opts)
+	if cached, ok := c.clients[account]; ok && cached.token == token && cached.opts == opts {
+		return cached.client, nil
+	}
+
+	cfg, err := NewConfiguration(opts)
+	if err != nil {
+		return nil, err
+	}
+	client := NewClient(sdk.NewAPIClient(cfg))
+	client.SetToken(token)
+	c.clients[account] = cachedClient{token: token, opts: opts, client: client}
+	return client, nil
+}
+
+// Cached returns the last Client built for account, whatever its token. It
+// lets Monitors delete their remote monitor after their account, or the Secret
+// holding its token, was deleted.
+func (c *ClientCache) Cached(account string) (*Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.clients[account]
+	return cached.client, ok
+}
+
+// Forget drops the Client of account.
+func (c *ClientCache) Forget(account string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clients, account)
+}
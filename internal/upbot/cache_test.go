@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClientCache", func() {
+	It("reuses the client of an account until its token changes", func() {
+		cache := NewClientCache(Options{})
+
+		first, err := cache.Get("UpbotAccount/team-a/main", "token-1", Options{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.Get("UpbotAccount/team-a/main", "token-1", Options{})).To(BeIdenticalTo(first))
+		Expect(first.Token()).To(Equal("token-1"))
+
+		other, err := cache.Get("UpbotAccount/team-b/main", "token-1", Options{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(BeIdenticalTo(first))
+
+		rotated, err := cache.Get("UpbotAccount/team-a/main", "token-2", Options{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated).NotTo(BeIdenticalTo(first))
+		Expect(rotated.Token()).To(Equal("token-2"))
+	})
+
+	It("keeps the last client of an account until it is forgotten", func() {
+		cache := NewClientCache(Options{})
+		_, ok := cache.Cached("UpbotAccount/team-a/main")
+		Expect(ok).To(BeFalse())
+
+		client, err := cache.Get("UpbotAccount/team-a/main", "token", Options{})
+		Expect(err).NotTo(HaveOccurred())
+		cached, ok := cache.Cached("UpbotAccount/team-a/main")
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeIdenticalTo(client))
+
+		cache.Forget("UpbotAccount/team-a/main")
+		_, ok = cache.Cached("UpbotAccount/team-a/main")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("merges the options of an account with the defaults", func() {
+		cache := NewClientCache(Options{BaseURL: "https://api.upbot.example", Timeout: 10 * time.Second})
+
+		client, err := cache.Get("UpbotAccount/team-a/main", "token", Options{
+			BaseURL:   "https://staging.upbot.example/",
+			UserAgent: "team-a",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		cfg := client.api.GetConfig()
+		Expect(cfg.Servers[0].URL).To(Equal("https://staging.upbot.example"))
+		Expect(cfg.UserAgent).To(Equal("team-a"))
+		Expect(cfg.HTTPClient.Timeout).To(Equal(10 * time.Second))
+
+		changed, err := cache.Get("UpbotAccount/team-a/main", "token", Options{UserAgent: "team-a/2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).NotTo(BeIdenticalTo(client))
+	})
+})
+
+var _ = Describe("NewConfiguration", func() {
+	It("routes requests through the proxy", func() {
+		cfg, err := NewConfiguration(Options{ProxyURL: "http://proxy.internal:3128"})
+		Expect(err).NotTo(HaveOccurred())
+
+		req, _ := http.NewRequest(http.MethodGet, "https://api.upbot.app/api/monitors", nil)
+		proxy, err := cfg.HTTPClient.Transport.(*http.Transport).Proxy(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proxy.String()).To(Equal("http://proxy.internal:3128"))
+	})
+
+	It("rejects invalid settings", func() {
+		_, err := NewConfiguration(Options{BaseURL: "api.upbot.app"})
+		Expect(err).To(MatchError(ContainSubstring("base URL")))
+
+		_, err = NewConfiguration(Options{CABundle: "not a certificate"})
+		Expect(err).To(MatchError(ContainSubstring("CA bundle")))
+	})
+})
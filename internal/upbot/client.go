@@ -0,0 +1,267 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upbot wraps the generated Upbot SDK. Monitors are created, updated
+// and deleted with the SDK request models; the listing is decoded here so that
+// it can be paged through and cached.
+package upbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	sdk "github.com/upbothq/upbot-go-sdk"
+)
+
+// Client talks to the Upbot API.
+type Client struct {
+	api *sdk.APIClient
+
+	mu    sync.RWMutex
+	token string
+}
+
+// NewClient returns a Client that sends requests with the configuration of api.
+func NewClient(api *sdk.APIClient) *Client {
+	return &Client{api: api}
+}
+
+// SetToken sets the API token sent with the following requests. It can be
+// called while requests are in flight.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Token returns the API token sent with requests.
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// ValidateToken checks that token is accepted by the API, without changing
+// the token of the client.
+func (c *Client) ValidateToken(ctx context.Context, token string) error {
+	probe := &Client{api: c.api, token: token}
+	return probe.do(ctx, http.MethodGet, "MonitorManagementAPIService.DisplayAListingOfTheResource",
+		"/api/monitors?page=1", nil, nil)
+}
+
+// MonitorRequest holds the settings of a monitor sent to create or update it,
+// the fields of the SDK request models.
+type MonitorRequest struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Target     string `json:"target,omitempty"`
+	Interval   string `json:"interval"`
+	RetryCount int32  `json:"retry_count"`
+}
+
+// Monitor is a monitor as returned by the Upbot API. The API only reports the
+// basic settings and whether the target is up, not the check results.
+type Monitor struct {
+	ID         string `json:"id"`
+	Name       string `json:"display_name"`
+	Type       string `json:"type"`
+	Target     string `json:"target"`
+	Interval   int32  `json:"interval"`
+	Status     string `json:"status"`
+	RetryCount int32  `json:"retry_count"`
+}
+
+// States of a monitor reported by Upbot.
+const (
+	StatusOnline  = "online"
+	StatusOffline = "offline"
+	StatusUnknown = "unknown"
+)
+
+// monitorPage is a page of the monitor listing.
+type monitorPage struct {
+	Data  []Monitor `json:"data"`
+	Links struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
+// ListMonitors returns all monitors of the account, following the pagination.
+func (c *Client) ListMonitors(ctx context.Context) ([]Monitor, error) {
+	var monitors []Monitor
+	err := c.listMonitors(ctx, func(page []Monitor) bool {
+		monitors = append(monitors, page...)
+		return true
+	})
+	return monitors, err
+}
+
+// listMonitors calls visit with each page of the listing until visit returns
+// false or there are no more pages.
+func (c *Client) listMonitors(ctx context.Context, visit func([]Monitor) bool) error {
+	for page := 1; ; page++ {
+		var resp monitorPage
+		if err := c.do(ctx, http.MethodGet, "MonitorManagementAPIService.DisplayAListingOfTheResource",
+			"/api/monitors?page="+strconv.Itoa(page), nil, &resp); err != nil {
+			return err
+		}
+		if !visit(resp.Data) || resp.Links.Next == nil || *resp.Links.Next == "" || len(resp.Data) == 0 {
+			return nil
+		}
+	}
+}
+
+// CreateMonitor creates a monitor and returns its ID.
+func (c *Client) CreateMonitor(ctx context.Context, monitor MonitorRequest) (string, error) {
+	request := sdk.NewStoreANewlyCreatedResourceInStorageRequest(monitor.Type)
+	request.SetName(monitor.Name)
+	request.SetTarget(monitor.Target)
+	request.SetInterval(monitor.Interval)
+	request.SetRetryCount(monitor.RetryCount)
+
+	start := time.Now()
+	created, httpResp, err := c.api.MonitorManagementAPI.StoreANewlyCreatedResourceInStorage(c.withToken(ctx)).
+		StoreANewlyCreatedResourceInStorageRequest(*request).Execute()
+	if err := observeSDKRequest("MonitorManagementAPIService.StoreANewlyCreatedResourceInStorage", start, httpResp, err); err != nil {
+		return "", err
+	}
+	if created == nil || created.Id == nil {
+		return "", fmt.Errorf("upbot API did not return the ID of the created monitor")
+	}
+	return *created.Id, nil
+}
+
+// UpdateMonitor replaces the settings of the monitor with the given ID.
+func (c *Client) UpdateMonitor(ctx context.Context, id string, monitor MonitorRequest) error {
+	request := sdk.NewUpdateTheSpecifiedResourceInStorageRequest()
+	request.SetName(monitor.Name)
+	request.SetType(monitor.Type)
+	request.SetTarget(monitor.Target)
+	request.SetInterval(monitor.Interval)
+	request.SetRetryCount(monitor.RetryCount)
+
+	start := time.Now()
+	httpResp, err := c.api.MonitorManagementAPI.UpdateTheSpecifiedResourceInStorage(c.withToken(ctx), id).
+		UpdateTheSpecifiedResourceInStorageRequest(*request).Execute()
+	return observeSDKRequest("MonitorManagementAPIService.UpdateTheSpecifiedResourceInStorage", start, httpResp, err)
+}
+
+// DeleteMonitor deletes the monitor with the given ID.
+func (c *Client) DeleteMonitor(ctx context.Context, id string) error {
+	start := time.Now()
+	_, httpResp, err := c.api.MonitorManagementAPI.DeleteASpecificMonitor(c.withToken(ctx), id).Execute()
+	return observeSDKRequest("MonitorManagementAPIService.DeleteASpecificMonitor", start, httpResp, err)
+}
+
+// withToken returns ctx carrying the API token for requests sent by the SDK.
+func (c *Client) withToken(ctx context.Context) context.Context {
+	if token := c.Token(); token != "" {
+		ctx = context.WithValue(ctx, sdk.ContextAccessToken, token)
+	}
+	return ctx
+}
+
+// observeSDKRequest records a request sent by the SDK and returns its error
+// converted by asAPIError.
+func observeSDKRequest(operation string, start time.Time, httpResp *http.Response, err error) error {
+	err = asAPIError(httpResp, err)
+	var statusCode int
+	if httpResp != nil {
+		statusCode = httpResp.StatusCode
+	}
+	observeRequest(operation, start, statusCode, err)
+	return err
+}
+
+// do sends body as JSON and decodes the response into out when it is not nil.
+func (c *Client) do(ctx context.Context, method, operation, path string, body, out any) error {
+	cfg := c.api.GetConfig()
+
+	basePath, err := cfg.ServerURLWithContext(ctx, operation)
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, basePath+path, reqBody)
+	if err != nil {
+		return err
+	}
+	for key, value := range cfg.DefaultHeader {
+		req.Header.Set(key, value)
+	}
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("User-Agent", cfg.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		observeRequest(operation, start, 0, err)
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err == nil && resp.StatusCode >= http.StatusMultipleChoices {
+		err = newAPIError(resp, respBody)
+	}
+	observeRequest(operation, start, resp.StatusCode, err)
+	if err != nil {
+		return err
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+	}
+	return nil
+}
+
+// asAPIError converts errors returned by the generated SDK into an *APIError
+// when the API answered with an error status.
+func asAPIError(httpResp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	var openAPIErr *sdk.GenericOpenAPIError
+	if httpResp != nil && httpResp.StatusCode >= http.StatusMultipleChoices && errors.As(err, &openAPIErr) {
+		return newAPIError(httpResp, openAPIErr.Body())
+	}
+	return err
+}
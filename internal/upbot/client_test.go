@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	sdk "github.com/upbothq/upbot-go-sdk"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server   *httptest.Server
+		client   *Client
+		handler  http.HandlerFunc
+		requests []*http.Request
+		bodies   []map[string]any
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		bodies = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			body := map[string]any{}
+			if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+				Expect(json.Unmarshal(data, &body)).To(Succeed())
+			}
+			bodies = append(bodies, body)
+			handler(w, r)
+		}))
+
+		cfg := sdk.NewConfiguration()
+		cfg.Servers = sdk.ServerConfigurations{{URL: server.URL}}
+		client = NewClient(sdk.NewAPIClient(cfg))
+		client.SetToken("token")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("sends only the fields of the SDK request model on create", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"abc"}`))
+		}
+
+		id, err := client.CreateMonitor(context.Background(), MonitorRequest{
+			Name:       "api",
+			Type:       "http",
+			Target:     "https://example.com/health",
+			Interval:   "60",
+			RetryCount: 2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("abc"))
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Method).To(Equal(http.MethodPost))
+		Expect(requests[0].URL.Path).To(Equal("/api/monitors"))
+		Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+		Expect(bodies[0]).To(Equal(map[string]any{
+			"name":        "api",
+			"type":        "http",
+			"target":      "https://example.com/health",
+			"interval":    "60",
+			"retry_count": float64(2),
+		}))
+	})
+
+	It("returns an APIError for error responses", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`Monitor does not exist.`))
+		}
+
+		err := client.UpdateMonitor(context.Background(), "missing", MonitorRequest{Name: "api", Type: "http"})
+		Expect(err).To(HaveOccurred())
+		Expect(IsNotFound(err)).To(BeTrue())
+		Expect(requests[0].Method).To(Equal(http.MethodPut))
+		Expect(requests[0].URL.Path).To(Equal("/api/monitors/missing"))
+		Expect(bodies[0]).To(HaveKeyWithValue("name", "api"))
+		Expect(bodies[0]).To(HaveKeyWithValue("type", "http"))
+
+		err = client.DeleteMonitor(context.Background(), "missing")
+		Expect(IsNotFound(err)).To(BeTrue())
+	})
+
+	It("follows the pagination when listing monitors", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("page") {
+			case "1":
+				_, _ = w.Write([]byte(`{"data":[{"id":"a","display_name":"one","interval":60}],"links":{"next":"/?page=2"}}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":[{"id":"b","display_name":"two","interval":300,"retry_count":2}],"links":{"next":null}}`))
+			}
+		}
+
+		monitors, err := client.ListMonitors(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(monitors).To(HaveLen(2))
+		Expect(monitors[1].Name).To(Equal("two"))
+		Expect(monitors[1].Interval).To(BeEquivalentTo(300))
+		Expect(monitors[1].RetryCount).To(BeEquivalentTo(2))
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("classifies errors and honors Retry-After", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodDelete {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"The target field is required."}`))
+		}
+
+		err := client.DeleteMonitor(context.Background(), "abc")
+		Expect(IsTransient(err)).To(BeTrue())
+		Expect(RetryAfter(err)).To(Equal(30 * time.Second))
+
+		err = client.UpdateMonitor(context.Background(), "abc", MonitorRequest{Name: "api", Type: "http"})
+		Expect(IsTransient(err)).To(BeFalse())
+		Expect(RetryAfter(err)).To(BeZero())
+
+		Expect(IsTransient(errors.New("connection refused"))).To(BeTrue())
+	})
+
+	It("explains validation failures and classifies API errors", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"The target field must be a valid URL. (and 1 more error)",` +
+				`"errors":{"target":["The target field must be a valid URL."],"interval":["The interval field must be at least 30."]}}`))
+		}
+
+		failures := testutil.ToFloat64(apiErrorsTotal.WithLabelValues("create_monitor", "ValidationFailed"))
+		_, err := client.CreateMonitor(context.Background(), MonitorRequest{Name: "api", Type: "http"})
+		Expect(testutil.ToFloat64(apiErrorsTotal.WithLabelValues("create_monitor", "ValidationFailed"))).To(Equal(failures + 1))
+		var apiErr *APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Kind()).To(Equal(ErrorKindValidationFailed))
+		Expect(apiErr.Message()).To(Equal("The interval field must be at least 30. The target field must be a valid URL."))
+
+		Expect(KindOf(&APIError{StatusCode: http.StatusUnauthorized})).To(Equal(ErrorKindUnauthorized))
+		Expect(KindOf(&APIError{StatusCode: http.StatusForbidden, Body: []byte(`{"message":"Monitor limit reached for your plan."}`)})).
+			To(Equal(ErrorKindQuotaExceeded))
+		Expect(KindOf(&APIError{StatusCode: http.StatusForbidden})).To(Equal(ErrorKindForbidden))
+		Expect(IsTransient(&APIError{StatusCode: http.StatusUnauthorized})).To(BeTrue())
+		Expect(IsTransient(&APIError{StatusCode: http.StatusForbidden})).To(BeFalse())
+		Expect(IsUnauthorized(&APIError{StatusCode: http.StatusForbidden})).To(BeTrue())
+		Expect(KindOf(&APIError{StatusCode: http.StatusTooManyRequests})).To(Equal(ErrorKindRateLimited))
+		Expect(KindOf(&APIError{StatusCode: http.StatusBadGateway})).To(Equal(ErrorKindServerError))
+		Expect(KindOf(errors.New("connection refused"))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sdk "github.com/upbothq/upbot-go-sdk"
+	"golang.org/x/time/rate"
+)
+
+// Options configure the HTTP client used to talk to the Upbot API. The zero
+// value uses the SDK defaults.
+type Options struct {
+	// BaseURL of the Upbot API. Defaults to the public API.
+	BaseURL string
+	// ProxyURL is the HTTP proxy requests go through. Defaults to the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// CABundle is a PEM bundle of certificate authorities trusted in addition
+	// to the system ones.
+	CABundle string
+	// Timeout of a request, including reading the response. 0 means no timeout.
+	Timeout time.Duration
+	// UserAgent sent with every request. Defaults to the SDK one.
+	UserAgent string
+	// RateLimiter, when set, delays requests to stay within its rate. It is
+	// shared by every client built from these options and can't be overridden
+	// per account.
+	RateLimiter *rate.Limiter
+}
+
+// Merge returns o with the fields set in override replaced.
+func (o Options) Merge(override Options) Options {
+	if override.BaseURL != "" {
+		o.BaseURL = override.BaseURL
+	}
+	if override.ProxyURL != "" {
+		o.ProxyURL = override.ProxyURL
+	}
+	if override.CABundle != "" {
+		o.CABundle = override.CABundle
+	}
+	if override.Timeout != 0 {
+		o.Timeout = override.Timeout
+	}
+	if override.UserAgent != "" {
+		o.UserAgent = override.UserAgent
+	}
+	return o
+}
+
+// NewConfiguration returns an SDK configuration whose HTTP client is built
+// from opts.
+func NewConfiguration(opts Options) (*sdk.Configuration, error) {
+	cfg := sdk.NewConfiguration()
+	if opts.BaseURL != "" {
+		if err := validateURL(opts.BaseURL); err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		cfg.Servers = sdk.ServerConfigurations{{URL: strings.TrimSuffix(opts.BaseURL, "/")}}
+	}
+	if opts.UserAgent != "" {
+		cfg.UserAgent = opts.UserAgent
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.ProxyURL != "" {
+		if err := validateURL(opts.ProxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		proxyURL, _ := url.Parse(opts.ProxyURL)
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if opts.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(opts.CABundle)) {
+			return nil, errors.New("invalid CA bundle: no PEM certificate found")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	var roundTripper http.RoundTripper = transport
+	if opts.RateLimiter != nil {
+		roundTripper = &rateLimitedTransport{limiter: opts.RateLimiter, next: transport}
+	}
+	cfg.HTTPClient = &http.Client{Transport: roundTripper, Timeout: opts.Timeout}
+	return cfg, nil
+}
+
+// rateLimitedTransport waits for the limiter before sending each request.
+type rateLimitedTransport struct {
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	rateLimiterDelay.Observe(time.Since(start).Seconds())
+	return t.next.RoundTrip(req)
+}
+
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an http or https URL", raw)
+	}
+	return nil
+}
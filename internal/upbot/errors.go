@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind classifies the errors returned by the Upbot API. The kinds are
+// valid condition reasons.
+type ErrorKind string
+
+const (
+	// ErrorKindNotFound means that the monitor doesn't exist.
+	ErrorKindNotFound ErrorKind = "NotFound"
+	// ErrorKindUnauthorized means that the token is invalid.
+	ErrorKindUnauthorized ErrorKind = "Unauthorized"
+	// ErrorKindForbidden means that the token isn't allowed to make the request.
+	ErrorKindForbidden ErrorKind = "Forbidden"
+	// ErrorKindValidationFailed means that the request was rejected as invalid.
+	ErrorKindValidationFailed ErrorKind = "ValidationFailed"
+	// ErrorKindQuotaExceeded means that the account reached a limit of its plan.
+	ErrorKindQuotaExceeded ErrorKind = "QuotaExceeded"
+	// ErrorKindRateLimited means that too many requests were sent.
+	ErrorKindRateLimited ErrorKind = "RateLimited"
+	// ErrorKindServerError means that the API failed to handle the request.
+	ErrorKindServerError ErrorKind = "ServerError"
+)
+
+// APIError is returned when the Upbot API answers with a non-2xx status code.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	// RetryAfter is the delay requested by the Retry-After header of a
+	// throttled or unavailable response, zero when the header is missing.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("upbot API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("upbot API returned %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message())
+}
+
+// errorBody is the JSON body of an error response. errors lists the
+// validation failures by field.
+type errorBody struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// Message returns the explanation given by the API, e.g. the validation
+// failures of each field, falling back to the raw body.
+func (e *APIError) Message() string {
+	var body errorBody
+	if err := json.Unmarshal(e.Body, &body); err != nil {
+		if message := strings.TrimSpace(string(e.Body)); message != "" {
+			return message
+		}
+		return http.StatusText(e.StatusCode)
+	}
+	if len(body.Errors) > 0 {
+		fields := make([]string, 0, len(body.Errors))
+		for field := range body.Errors {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+		var messages []string
+		for _, field := range fields {
+			messages = append(messages, body.Errors[field]...)
+		}
+		return strings.Join(messages, " ")
+	}
+	if body.Message != "" {
+		return body.Message
+	}
+	return http.StatusText(e.StatusCode)
+}
+
+// Kind classifies the error, or returns "" for unexpected status codes.
+func (e *APIError) Kind() ErrorKind {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrorKindNotFound
+	case e.StatusCode == http.StatusPaymentRequired:
+		return ErrorKindQuotaExceeded
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrorKindRateLimited
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrorKindUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		// Upbot also answers 403 when the plan doesn't allow more monitors.
+		if strings.Contains(strings.ToLower(e.Message()), "limit") {
+			return ErrorKindQuotaExceeded
+		}
+		return ErrorKindForbidden
+	case e.StatusCode == http.StatusBadRequest, e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrorKindValidationFailed
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrorKindServerError
+	default:
+		return ""
+	}
+}
+
+// KindOf returns the kind of the API error wrapped by err, or "" when err
+// doesn't come from an API response.
+func KindOf(err error) ErrorKind {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind()
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	return KindOf(err) == ErrorKindNotFound
+}
+
+// IsUnauthorized reports whether the API rejected the token.
+func IsUnauthorized(err error) bool {
+	kind := KindOf(err)
+	return kind == ErrorKindUnauthorized || kind == ErrorKindForbidden
+}
+
+// IsTransient reports whether the call that returned err may succeed when
+// retried as is. Network errors, throttling, server errors and invalid
+// credentials, which can be rotated, are transient; a failed validation, a
+// forbidden request or an exceeded quota is permanent.
+func IsTransient(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.Kind() {
+	case ErrorKindRateLimited, ErrorKindServerError, ErrorKindUnauthorized:
+		return true
+	case "":
+		return apiErr.StatusCode == http.StatusRequestTimeout
+	default:
+		return false
+	}
+}
+
+// RetryAfter returns the delay the API asked to wait before retrying the call
+// that returned err, or zero.
+func RetryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// newAPIError returns the error of a response with an error status.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	return &APIError{StatusCode: resp.StatusCode, Body: body, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "upbot_api_request_duration_seconds",
+		Help:    "Latency of the requests to the Upbot API by operation and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "code"})
+
+	apiErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upbot_api_errors_total",
+		Help: "Failed requests to the Upbot API by operation and error kind.",
+	}, []string{"operation", "kind"})
+
+	apiRateLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upbot_api_rate_limited_total",
+		Help: "Requests to the Upbot API rejected with 429 Too Many Requests, by operation.",
+	}, []string{"operation"})
+
+	rateLimiterDelay = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "upbot_api_rate_limiter_delay_seconds",
+		Help:    "Time requests to the Upbot API waited for the client-side rate limiter.",
+		Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequestDuration, apiErrorsTotal, apiRateLimitedTotal, rateLimiterDelay)
+}
+
+// observeRequest records the outcome of a request to the API. statusCode is
+// zero when no response was received, and err is nil when the request succeeded.
+func observeRequest(operation string, start time.Time, statusCode int, err error) {
+	operation = operationLabel(operation)
+
+	code := "none"
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	apiRequestDuration.WithLabelValues(operation, code).Observe(time.Since(start).Seconds())
+
+	if err == nil {
+		return
+	}
+	kind := KindOf(err)
+	if kind == "" {
+		kind = "Other"
+	}
+	apiErrorsTotal.WithLabelValues(operation, string(kind)).Inc()
+	if kind == ErrorKindRateLimited {
+		apiRateLimitedTotal.WithLabelValues(operation).Inc()
+	}
+}
+
+// operationLabel shortens the SDK operation names, e.g.
+// "MonitorManagementAPIService.DeleteASpecificMonitor", to the action they
+// perform.
+func operationLabel(operation string) string {
+	switch strings.TrimPrefix(operation, "MonitorManagementAPIService.") {
+	case "DisplayAListingOfTheResource":
+		return "list_monitors"
+	case "StoreANewlyCreatedResourceInStorage":
+		return "create_monitor"
+	case "UpdateTheSpecifiedResourceInStorage":
+		return "update_monitor"
+	case "DeleteASpecificMonitor":
+		return "delete_monitor"
+	default:
+		return operation
+	}
+}
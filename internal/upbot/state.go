@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"sync"
+	"time"
+)
+
+// StateCache holds the last listing of the monitors of each account, so that
+// their state can be read without calling the API.
+type StateCache struct {
+	mu       sync.RWMutex
+	accounts map[string]accountState
+}
+
+type accountState struct {
+	fetchedAt time.Time
+	monitors  map[string]Monitor
+}
+
+// NewStateCache returns an empty StateCache.
+func NewStateCache() *StateCache {
+	return &StateCache{accounts: map[string]accountState{}}
+}
+
+// Set replaces the monitors of account with a listing fetched at fetchedAt.
+func (c *StateCache) Set(account string, monitors []Monitor, fetchedAt time.Time) {
+	byID := make(map[string]Monitor, len(monitors))
+	for _, monitor := range monitors {
+		byID[monitor.ID] = monitor
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts[account] = accountState{fetchedAt: fetchedAt, monitors: byID}
+}
+
+// Get returns the monitor of account with the given ID and when it was
+// fetched. ok is false when the monitor wasn't part of the last listing.
+func (c *StateCache) Get(account, id string) (monitor Monitor, fetchedAt time.Time, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	state, found := c.accounts[account]
+	if !found {
+		return Monitor{}, time.Time{}, false
+	}
+	monitor, ok = state.monitors[id]
+	return monitor, state.fetchedAt, ok
+}
+
+// List returns the monitors of account and when they were fetched. ok is
+// false when the account wasn't listed yet.
+func (c *StateCache) List(account string) (monitors []Monitor, fetchedAt time.Time, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	state, ok := c.accounts[account]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	monitors = make([]Monitor, 0, len(state.monitors))
+	for _, monitor := range state.monitors {
+		monitors = append(monitors, monitor)
+	}
+	return monitors, state.fetchedAt, true
+}
+
+// Forget drops the monitors of account.
+func (c *StateCache) Forget(account string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.accounts, account)
+}
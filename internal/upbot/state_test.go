@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateCache", func() {
+	It("keeps the last listing of each account", func() {
+		var monitors []Monitor
+		Expect(json.Unmarshal([]byte(`[
+			{"id":"a","display_name":"shop","status":"online"},
+			{"id":"b","display_name":"blog","status":"offline"}
+		]`), &monitors)).To(Succeed())
+
+		cache := NewStateCache()
+		fetchedAt := time.Now()
+		cache.Set("", monitors, fetchedAt)
+
+		a, at, ok := cache.Get("", "a")
+		Expect(ok).To(BeTrue())
+		Expect(at).To(Equal(fetchedAt))
+		Expect(a.Name).To(Equal("shop"))
+		Expect(a.Status).To(Equal(StatusOnline))
+
+		b, _, ok := cache.Get("", "b")
+		Expect(ok).To(BeTrue())
+		Expect(b.Status).To(Equal(StatusOffline))
+
+		_, _, ok = cache.Get("UpbotAccount/team-a/main", "a")
+		Expect(ok).To(BeFalse())
+		listed, at, ok := cache.List("")
+		Expect(ok).To(BeTrue())
+		Expect(at).To(Equal(fetchedAt))
+		Expect(listed).To(HaveLen(2))
+		_, _, ok = cache.List("UpbotAccount/team-a/main")
+		Expect(ok).To(BeFalse())
+
+		cache.Set("", monitors[:1], time.Now())
+		_, _, ok = cache.Get("", "b")
+		Expect(ok).To(BeFalse())
+	})
+})
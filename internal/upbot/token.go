@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// TokenSource loads the current API token.
+type TokenSource func(ctx context.Context) (string, error)
+
+// FileTokenSource reads the token from a file, e.g. a mounted Secret.
+func FileTokenSource(path string) TokenSource {
+	return func(context.Context) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+}
+
+// SecretTokenSource reads the token from a key of a Secret.
+func SecretTokenSource(reader client.Reader, name types.NamespacedName, key string) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		var secret corev1.Secret
+		if err := reader.Get(ctx, name, &secret); err != nil {
+			return "", err
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in secret %s", key, name)
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+}
+
+// StaticTokenSource always returns the same token.
+func StaticTokenSource(token string) TokenSource {
+	return func(context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// DefaultRevalidateEvery is the default TokenReloader.RevalidateEvery.
+const DefaultRevalidateEvery = 20
+
+// TokenReloader keeps the token of a Client up to date with a TokenSource.
+// A new token is validated against the API before it replaces the current one,
+// so a bad rotation does not break a working client.
+type TokenReloader struct {
+	Client *Client
+	Source TokenSource
+	// Interval is the time between two reads of the source.
+	Interval time.Duration
+	// RevalidateEvery is the number of reloads after which the token is
+	// validated again even if it didn't change, so that a revoked token makes
+	// the operator unready and a rejected one is retried. 0 never does.
+	RevalidateEvery int
+
+	mu      sync.Mutex
+	reloads int
+	// invalid is the last token rejected by the API, so that it is not
+	// validated again on every read.
+	invalid string
+	// err is the reason the client has no valid token, nil once it has one.
+	err error
+}
+
+// NewTokenReloader returns a TokenReloader whose client has no valid token yet.
+func NewTokenReloader(client *Client, source TokenSource, interval time.Duration) *TokenReloader {
+	return &TokenReloader{
+		Client:          client,
+		Source:          source,
+		Interval:        interval,
+		RevalidateEvery: DefaultRevalidateEvery,
+		err:             errors.New("upbot token not loaded yet"),
+	}
+}
+
+// NeedLeaderElection makes every replica keep its own token up to date.
+func (r *TokenReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reloads the token every Interval until ctx is cancelled.
+func (r *TokenReloader) Start(ctx context.Context) error {
+	logger := logf.FromContext(ctx).WithName("token-reloader")
+	wait.UntilWithContext(logf.IntoContext(ctx, logger), func(ctx context.Context) {
+		if err := r.Reload(ctx); err != nil {
+			logger.Error(err, "Failed to reload the Upbot token")
+		}
+	}, r.Interval)
+	return nil
+}
+
+// Reload reads the token from the source and, if it changed, validates it and
+// hands it to the client. The current token is kept when the new one is
+// rejected by the API or cannot be validated. Every RevalidateEvery reloads,
+// the token is validated whether it changed or not.
+func (r *TokenReloader) Reload(ctx context.Context) error {
+	logger := logf.FromContext(ctx)
+
+	token, err := r.Source(ctx)
+	if err != nil {
+		return fmt.Errorf("reading token: %w", err)
+	}
+	if token == "" {
+		return errors.New("token is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloads++
+	revalidate := r.RevalidateEvery > 0 && r.reloads%r.RevalidateEvery == 0
+	if !revalidate && (token == r.invalid || (token == r.Client.Token() && r.err == nil)) {
+		return nil
+	}
+
+	if err := r.Client.ValidateToken(ctx, token); err != nil {
+		if !IsUnauthorized(err) {
+			// The API could not be reached, try again on the next read.
+			return fmt.Errorf("validating token: %w", err)
+		}
+		r.invalid = token
+		if token == r.Client.Token() {
+			// The current token was revoked, there is nothing to fall back to.
+			r.err = fmt.Errorf("upbot token revoked by the API: %w", err)
+			return r.err
+		}
+		if r.err != nil {
+			r.err = fmt.Errorf("upbot token rejected by the API: %w", err)
+		}
+		return fmt.Errorf("new token rejected by the API, keeping the current one: %w", err)
+	}
+
+	if r.Client.Token() != "" && r.Client.Token() != token {
+		logger.Info("Rotated the Upbot token")
+	}
+	r.Client.SetToken(token)
+	r.invalid = ""
+	r.err = nil
+	return nil
+}
+
+// Check reports whether the client has a token accepted by the API. It can be
+// used as a readiness check.
+func (r *TokenReloader) Check(*http.Request) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdk "github.com/upbothq/upbot-go-sdk"
+)
+
+var _ = Describe("TokenReloader", func() {
+	var (
+		server    *httptest.Server
+		client    *Client
+		tokenFile string
+		reloader  *TokenReloader
+		accepted  map[string]bool
+	)
+
+	BeforeEach(func() {
+		accepted = map[string]bool{"Bearer good-1": true, "Bearer good-2": true}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !accepted[r.Header.Get("Authorization")] {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"Unauthenticated."}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}))
+
+		cfg := sdk.NewConfiguration()
+		cfg.Servers = sdk.ServerConfigurations{{URL: server.URL}}
+		client = NewClient(sdk.NewAPIClient(cfg))
+
+		tokenFile = filepath.Join(GinkgoT().TempDir(), "token")
+		reloader = NewTokenReloader(client, FileTokenSource(tokenFile), 0)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	writeToken := func(token string) {
+		Expect(os.WriteFile(tokenFile, []byte(token+"\n"), 0o600)).To(Succeed())
+	}
+
+	It("is not ready until a token is accepted by the API", func() {
+		Expect(reloader.Check(nil)).To(HaveOccurred())
+
+		writeToken("bad")
+		Expect(reloader.Reload(context.Background())).To(HaveOccurred())
+		Expect(reloader.Check(nil)).To(MatchError(ContainSubstring("rejected")))
+		Expect(client.Token()).To(BeEmpty())
+
+		writeToken("good-1")
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		Expect(reloader.Check(nil)).To(Succeed())
+		Expect(client.Token()).To(Equal("good-1"))
+	})
+
+	It("rotates to a valid token and keeps the current one when the new one is rejected", func() {
+		writeToken("good-1")
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+
+		writeToken("good-2")
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		Expect(client.Token()).To(Equal("good-2"))
+
+		writeToken("bad")
+		Expect(reloader.Reload(context.Background())).To(HaveOccurred())
+		Expect(client.Token()).To(Equal("good-2"))
+		Expect(reloader.Check(nil)).To(Succeed())
+
+		// The rejected token is not validated again until it changes.
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+	})
+
+	It("validates the token again every RevalidateEvery reloads", func() {
+		reloader.RevalidateEvery = 2
+		writeToken("bad")
+		Expect(reloader.Reload(context.Background())).To(HaveOccurred())
+
+		// A token rejected by mistake is retried.
+		accepted["Bearer bad"] = true
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		Expect(client.Token()).To(Equal("bad"))
+		Expect(reloader.Check(nil)).To(Succeed())
+
+		// A revoked token makes the reloader unready.
+		delete(accepted, "Bearer bad")
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		Expect(reloader.Reload(context.Background())).To(MatchError(ContainSubstring("revoked")))
+		Expect(reloader.Check(nil)).To(MatchError(ContainSubstring("revoked")))
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var monitorlog = logf.Log.WithName("monitor-resource")
+
+// SetupMonitorWebhookWithManager registers the webhook for Monitor in the manager.
+func SetupMonitorWebhookWithManager(mgr ctrl.Manager, validator *MonitorCustomValidator) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&monitoringv1alpha1.Monitor{}).
+		WithValidator(validator).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-monitoring-upbot-app-v1alpha1-monitor,mutating=false,failurePolicy=fail,sideEffects=None,groups=monitoring.upbot.app,resources=monitors,verbs=create;update,versions=v1alpha1,name=vmonitor-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// MonitorCustomValidator validates the parts of a Monitor that depend on the
+// operator configuration and therefore can't be expressed in the CRD schema.
+type MonitorCustomValidator struct {
+	// MinInterval and MaxInterval bound spec.interval; zero disables the bound.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+var _ webhook.CustomValidator = &MonitorCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Monitor.
+func (v *MonitorCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	monitor, ok := obj.(*monitoringv1alpha1.Monitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a Monitor object but got %T", obj)
+	}
+	monitorlog.Info("Validation for Monitor upon creation", "name", monitor.GetName())
+
+	return nil, v.validateMonitor(monitor)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Monitor.
+func (v *MonitorCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	monitor, ok := newObj.(*monitoringv1alpha1.Monitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a Monitor object for the newObj but got %T", newObj)
+	}
+	oldMonitor, ok := oldObj.(*monitoringv1alpha1.Monitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a Monitor object for the oldObj but got %T", oldObj)
+	}
+	monitorlog.Info("Validation for Monitor upon update", "name", monitor.GetName())
+
+	// The bounds only apply to intervals being set, otherwise tightening them
+	// would make every existing Monitor impossible to update, including to
+	// remove its finalizer.
+	if monitor.Spec.Interval.Duration == oldMonitor.Spec.Interval.Duration {
+		return nil, nil
+	}
+
+	return nil, v.validateMonitor(monitor)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Monitor.
+func (v *MonitorCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *MonitorCustomValidator) validateMonitor(monitor *monitoringv1alpha1.Monitor) error {
+	var allErrs field.ErrorList
+
+	intervalPath := field.NewPath("spec", "interval")
+	interval := monitor.Spec.Interval.Duration
+	switch {
+	case interval <= 0:
+		allErrs = append(allErrs, field.Invalid(intervalPath, monitor.Spec.Interval.String(), "must be positive"))
+	case v.MinInterval > 0 && interval < v.MinInterval:
+		allErrs = append(allErrs, field.Invalid(intervalPath, monitor.Spec.Interval.String(),
+			fmt.Sprintf("must be at least %s", v.MinInterval)))
+	case v.MaxInterval > 0 && interval > v.MaxInterval:
+		allErrs = append(allErrs, field.Invalid(intervalPath, monitor.Spec.Interval.String(),
+			fmt.Sprintf("must be at most %s", v.MaxInterval)))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(monitoringv1alpha1.GroupVersion.WithKind("Monitor").GroupKind(), monitor.Name, allErrs)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "github.com/upbothq/operator/api/v1alpha1"
+)
+
+var _ = Describe("Monitor Webhook", func() {
+	var (
+		obj       *monitoringv1alpha1.Monitor
+		validator MonitorCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &monitoringv1alpha1.Monitor{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-monitor", Namespace: "default"},
+			Spec: monitoringv1alpha1.MonitorSpec{
+				Type:     monitoringv1alpha1.MonitorTypeHTTP,
+				Target:   "https://example.com",
+				Interval: monitoringv1alpha1.Interval{Duration: time.Minute},
+			},
+		}
+		validator = MonitorCustomValidator{
+			MinInterval: 30 * time.Second,
+			MaxInterval: 10 * time.Minute,
+		}
+	})
+
+	Context("When creating or updating Monitor under Validating Webhook", func() {
+		It("Should admit an interval within the bounds", func() {
+			Expect(validator.ValidateCreate(context.Background(), obj)).To(BeNil())
+		})
+
+		It("Should deny an interval below the minimum", func() {
+			obj.Spec.Interval = monitoringv1alpha1.Interval{Duration: 10 * time.Second}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("must be at least 30s")))
+		})
+
+		It("Should deny an interval above the maximum on update", func() {
+			oldObj := obj.DeepCopy()
+			obj.Spec.Interval = monitoringv1alpha1.Interval{Duration: time.Hour}
+			_, err := validator.ValidateUpdate(context.Background(), oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("must be at most 10m0s")))
+		})
+
+		It("Should admit updates that keep an interval outside the bounds", func() {
+			obj.Spec.Interval = monitoringv1alpha1.Interval{Duration: time.Hour}
+			oldObj := obj.DeepCopy()
+			obj.Labels = map[string]string{"team": "shop"}
+			Expect(validator.ValidateUpdate(context.Background(), oldObj, obj)).To(BeNil())
+		})
+
+		It("Should not enforce bounds that are disabled", func() {
+			validator.MaxInterval = 0
+			obj.Spec.Interval = monitoringv1alpha1.Interval{Duration: time.Hour}
+			Expect(validator.ValidateCreate(context.Background(), obj)).To(BeNil())
+		})
+	})
+})